@@ -0,0 +1,19 @@
+action "towerops_device_rediscover" "router" {
+  config {
+    device_id = towerops_device.router.id
+    wait      = true
+  }
+}
+
+resource "towerops_device" "router" {
+  site_id    = towerops_site.example.id
+  name       = "Core Router"
+  ip_address = "192.168.1.1"
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.towerops_device_rediscover.router]
+    }
+  }
+}
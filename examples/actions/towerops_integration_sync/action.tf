@@ -0,0 +1,18 @@
+action "towerops_integration_sync" "billing" {
+  config {
+    integration_id = towerops_integration.billing.id
+    fail_on_errors = true
+  }
+}
+
+resource "towerops_integration" "billing" {
+  provider_type         = "webhook"
+  sync_interval_minutes = 60
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.towerops_integration_sync.billing]
+    }
+  }
+}
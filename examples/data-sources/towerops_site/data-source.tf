@@ -0,0 +1,19 @@
+# Look up a site by its exact name.
+data "towerops_site" "noc" {
+  name = "Network Operations Center"
+}
+
+# Look up the site nearest to a point, within 25 km of it.
+data "towerops_site" "nearest" {
+  near = {
+    latitude  = 41.8781
+    longitude = -87.6298
+    radius_km = 25
+  }
+}
+
+resource "towerops_device" "router" {
+  site_id    = data.towerops_site.noc.id
+  name       = "Core Router"
+  ip_address = "192.168.1.1"
+}
@@ -0,0 +1,25 @@
+# Every tower in the north region inside the Chicago area.
+data "towerops_sites" "chicago_towers" {
+  name_regex = "^Tower "
+
+  labels = {
+    region = "north"
+  }
+
+  bounding_box = {
+    north = 42.5
+    south = 41.5
+    east  = -87.5
+    west  = -88.5
+  }
+}
+
+# One maintenance window per matching site.
+resource "towerops_maintenance_window" "firmware" {
+  for_each = data.towerops_sites.chicago_towers.ids_by_name
+
+  name      = "Firmware Upgrade - ${each.key}"
+  starts_at = "2024-03-15T02:00:00Z"
+  ends_at   = "2024-03-15T06:00:00Z"
+  site_id   = each.value
+}
@@ -0,0 +1,20 @@
+resource "towerops_agent" "office" {
+  name = "Office Poller"
+}
+
+ephemeral "towerops_agent_token" "office" {
+  agent_id = towerops_agent.office.id
+}
+
+# Enroll the agent with a token that never appears in plan or state. The
+# token is revoked when the run ends.
+resource "terraform_data" "enroll" {
+  triggers_replace = [towerops_agent.office.id]
+
+  provisioner "local-exec" {
+    command = "ssh poller.office.example.net towerops-agent enroll"
+    environment = {
+      TOWEROPS_AGENT_TOKEN = ephemeral.towerops_agent_token.office.token
+    }
+  }
+}
@@ -0,0 +1,18 @@
+ephemeral "towerops_api_token" "inventory" {
+  scopes      = ["devices:read", "sites:read"]
+  ttl_seconds = 900
+  description = "Ansible inventory"
+}
+
+# Hand the scoped token to a downstream tool without it ever appearing in
+# plan or state. The token is revoked when the run ends.
+resource "terraform_data" "configure_pollers" {
+  triggers_replace = [timestamp()]
+
+  provisioner "local-exec" {
+    command = "ansible-playbook -i towerops_inventory.yml pollers.yml"
+    environment = {
+      TOWEROPS_TOKEN = ephemeral.towerops_api_token.inventory.token
+    }
+  }
+}
@@ -0,0 +1,7 @@
+list "towerops_agent" "all" {
+  provider = towerops
+
+  config {
+    name_prefix = "Remote"
+  }
+}
@@ -0,0 +1,8 @@
+list "towerops_device" "all" {
+  provider = towerops
+
+  config {
+    site_id  = "7c9e6679-7425-40de-944b-e07fc1f90ae7"
+    ip_range = "10.20.0.0/16"
+  }
+}
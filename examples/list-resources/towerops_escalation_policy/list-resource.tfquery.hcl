@@ -0,0 +1,7 @@
+list "towerops_escalation_policy" "all" {
+  provider = towerops
+
+  config {
+    name_prefix = "Critical"
+  }
+}
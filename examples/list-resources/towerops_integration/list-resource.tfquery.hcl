@@ -0,0 +1,7 @@
+list "towerops_integration" "all" {
+  provider = towerops
+
+  config {
+    provider_type = "webhook"
+  }
+}
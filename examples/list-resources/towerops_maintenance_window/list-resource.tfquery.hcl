@@ -0,0 +1,7 @@
+list "towerops_maintenance_window" "all" {
+  provider = towerops
+
+  config {
+    name_prefix = "Firmware"
+  }
+}
@@ -0,0 +1,7 @@
+list "towerops_schedule" "all" {
+  provider = towerops
+
+  config {
+    name_prefix = "NOC"
+  }
+}
@@ -0,0 +1,7 @@
+list "towerops_site" "all" {
+  provider = towerops
+
+  config {
+    name_prefix = "Tower"
+  }
+}
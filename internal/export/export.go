@@ -0,0 +1,416 @@
+// Package export generates Terraform configuration and import blocks for the
+// objects that already exist in a TowerOps organization.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/netip"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/towerops/terraform-provider-towerops/internal/provider"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Export reads every object in the organization of client and writes
+// Terraform configuration for them to outDir, one file per resource type,
+// together with imports.tf containing an import block for each resource.
+// Secrets cannot be exported; a warning is written to warn for each one that
+// was left out. Export refuses to overwrite existing files.
+func Export(client *provider.Client, outDir string, warn io.Writer) error {
+	e := &exporter{
+		client:  client,
+		warn:    warn,
+		names:   make(names),
+		sites:   make(map[string]string),
+		devices: make(map[string]string),
+		files:   make(map[string]*hclwrite.File),
+	}
+
+	steps := []func() error{
+		e.exportOrganization,
+		e.exportSites,
+		e.exportDevices,
+		e.exportSchedules,
+		e.exportEscalationPolicies,
+		e.exportAgents,
+		e.exportIntegrations,
+		e.exportMaintenanceWindows,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+
+	e.providerConfig()
+
+	return e.write(outDir)
+}
+
+type exporter struct {
+	client *provider.Client
+	warn   io.Writer
+	names  names
+
+	// sites and devices map object IDs to resource names so that other
+	// resources can reference them.
+	sites   map[string]string
+	devices map[string]string
+
+	files   map[string]*hclwrite.File
+	order   []string
+	imports *hclwrite.File
+}
+
+// resource appends a resource block of type typ to file and, when id is not
+// empty, a matching import block to imports.tf.
+func (e *exporter) resource(file, typ, name, id string) *hclwrite.Body {
+	f, ok := e.files[file]
+	if !ok {
+		f = hclwrite.NewEmptyFile()
+		e.files[file] = f
+		e.order = append(e.order, file)
+	}
+
+	body := f.Body()
+	if len(body.Blocks()) > 0 {
+		body.AppendNewline()
+	}
+	block := body.AppendNewBlock("resource", []string{typ, name})
+
+	if id != "" {
+		if e.imports == nil {
+			e.imports = hclwrite.NewEmptyFile()
+		}
+		imports := e.imports.Body()
+		if len(imports.Blocks()) > 0 {
+			imports.AppendNewline()
+		}
+		importBlock := imports.AppendNewBlock("import", nil).Body()
+		importBlock.SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: typ},
+			hcl.TraverseAttr{Name: name},
+		})
+		importBlock.SetAttributeValue("id", cty.StringVal(id))
+	}
+
+	return block.Body()
+}
+
+func (e *exporter) warnf(format string, args ...any) {
+	fmt.Fprintf(e.warn, "Warning: "+format+"\n", args...)
+}
+
+func (e *exporter) exportOrganization() error {
+	org, err := e.client.GetOrganization()
+	if err != nil {
+		return fmt.Errorf("reading organization: %w", err)
+	}
+
+	// The organization resource manages the settings of the token's
+	// organization and is not imported: creating it adopts the organization.
+	body := e.resource("organization.tf", "towerops_organization", "this", "")
+	body.SetAttributeValue("name", cty.StringVal(org.Name))
+	body.SetAttributeValue("use_sites", cty.BoolVal(org.UseSites))
+
+	if org.SnmpCommunity != "" {
+		e.warnf("towerops_organization.this: snmp_community was not exported. Set snmp_community_wo and snmp_community_wo_version to manage it.")
+	}
+
+	return nil
+}
+
+func (e *exporter) exportSites() error {
+	sites, err := e.client.ListSites()
+	if err != nil {
+		return fmt.Errorf("listing sites: %w", err)
+	}
+
+	for _, site := range sites {
+		name := e.names.next("towerops_site", site.Name, "site")
+		e.sites[site.ID] = name
+
+		body := e.resource("sites.tf", "towerops_site", name, site.ID)
+		body.SetAttributeValue("name", cty.StringVal(site.Name))
+		setString(body, "location", site.Location)
+		setString(body, "address", site.Address)
+		if site.Latitude != nil {
+			body.SetAttributeValue("latitude", cty.NumberFloatVal(*site.Latitude))
+		}
+		if site.Longitude != nil {
+			body.SetAttributeValue("longitude", cty.NumberFloatVal(*site.Longitude))
+		}
+		setLabels(body, site.Labels)
+
+		if site.SNMPCommunity != nil {
+			e.warnf("towerops_site.%s: snmp_community was not exported. Set snmp_community_wo and snmp_community_wo_version to manage it.", name)
+		}
+	}
+
+	return nil
+}
+
+func (e *exporter) exportDevices() error {
+	devices, err := e.client.ListDevices("")
+	if err != nil {
+		return fmt.Errorf("listing devices: %w", err)
+	}
+
+	for _, device := range devices {
+		label := device.IPAddress
+		if device.Name != nil && *device.Name != "" {
+			label = *device.Name
+		}
+		name := e.names.next("towerops_device", label, "device")
+		e.devices[device.ID] = name
+
+		body := e.resource("devices.tf", "towerops_device", name, device.ID)
+		if device.SiteID != nil {
+			e.reference(body, "site_id", e.sites, "towerops_site", *device.SiteID)
+		} else {
+			body.SetAttributeTraversal("organization_id", hcl.Traversal{
+				hcl.TraverseRoot{Name: "towerops_organization"},
+				hcl.TraverseAttr{Name: "this"},
+				hcl.TraverseAttr{Name: "id"},
+			})
+		}
+		setString(body, "name", device.Name)
+		body.SetAttributeValue("ip_address", cty.StringVal(device.IPAddress))
+		if _, err := netip.ParseAddr(device.IPAddress); err != nil {
+			body.SetAttributeValue("allow_hostname", cty.True)
+		}
+		setString(body, "description", device.Description)
+		// These are written even when they match the provider's defaults,
+		// since device_defaults may change those defaults later.
+		if device.MonitoringEnabled != nil {
+			body.SetAttributeValue("monitoring_enabled", cty.BoolVal(*device.MonitoringEnabled))
+		}
+		if device.SNMPEnabled != nil {
+			body.SetAttributeValue("snmp_enabled", cty.BoolVal(*device.SNMPEnabled))
+		}
+		setString(body, "snmp_version", device.SNMPVersion)
+		if device.SNMPPort != nil {
+			body.SetAttributeValue("snmp_port", cty.NumberIntVal(int64(*device.SNMPPort)))
+		}
+		setString(body, "snmpv3_security_level", device.SNMPv3SecurityLevel)
+		setString(body, "snmpv3_username", device.SNMPv3Username)
+		setString(body, "snmpv3_auth_protocol", device.SNMPv3AuthProtocol)
+		setString(body, "snmpv3_priv_protocol", device.SNMPv3PrivProtocol)
+		setLabels(body, device.Labels)
+
+		if device.SNMPv3AuthPassword != nil || device.SNMPv3PrivPassword != nil {
+			e.warnf("towerops_device.%s: SNMPv3 passwords were not exported. Set snmpv3_auth_password_wo and snmpv3_priv_password_wo with their _wo_version attributes to manage them.", name)
+		}
+	}
+
+	return nil
+}
+
+func (e *exporter) exportSchedules() error {
+	schedules, err := e.client.ListSchedules()
+	if err != nil {
+		return fmt.Errorf("listing schedules: %w", err)
+	}
+
+	for _, schedule := range schedules {
+		name := e.names.next("towerops_schedule", schedule.Name, "schedule")
+
+		body := e.resource("schedules.tf", "towerops_schedule", name, schedule.ID)
+		body.SetAttributeValue("name", cty.StringVal(schedule.Name))
+		setString(body, "description", schedule.Description)
+		body.SetAttributeValue("timezone", cty.StringVal(schedule.Timezone))
+		setLabels(body, schedule.Labels)
+	}
+
+	return nil
+}
+
+func (e *exporter) exportEscalationPolicies() error {
+	policies, err := e.client.ListEscalationPolicies()
+	if err != nil {
+		return fmt.Errorf("listing escalation policies: %w", err)
+	}
+
+	for _, policy := range policies {
+		name := e.names.next("towerops_escalation_policy", policy.Name, "escalation_policy")
+
+		body := e.resource("escalation_policies.tf", "towerops_escalation_policy", name, policy.ID)
+		body.SetAttributeValue("name", cty.StringVal(policy.Name))
+		setString(body, "description", policy.Description)
+		if policy.RepeatCount != nil {
+			body.SetAttributeValue("repeat_count", cty.NumberIntVal(int64(*policy.RepeatCount)))
+		}
+		setLabels(body, policy.Labels)
+	}
+
+	return nil
+}
+
+func (e *exporter) exportAgents() error {
+	agents, err := e.client.ListAgents()
+	if err != nil {
+		return fmt.Errorf("listing agents: %w", err)
+	}
+
+	for _, agent := range agents {
+		name := e.names.next("towerops_agent", agent.Name, "agent")
+
+		body := e.resource("agents.tf", "towerops_agent", name, agent.ID)
+		body.SetAttributeValue("name", cty.StringVal(agent.Name))
+		setLabels(body, agent.Labels)
+	}
+
+	return nil
+}
+
+func (e *exporter) exportIntegrations() error {
+	integrations, err := e.client.ListIntegrations()
+	if err != nil {
+		return fmt.Errorf("listing integrations: %w", err)
+	}
+
+	for _, integration := range integrations {
+		name := e.names.next("towerops_integration", integration.Provider, "integration")
+
+		body := e.resource("integrations.tf", "towerops_integration", name, integration.ID)
+		body.SetAttributeValue("provider_type", cty.StringVal(integration.Provider))
+		if integration.Enabled != nil && !*integration.Enabled {
+			body.SetAttributeValue("enabled", cty.False)
+		}
+		if integration.SyncIntervalMinutes != nil {
+			body.SetAttributeValue("sync_interval_minutes", cty.NumberIntVal(int64(*integration.SyncIntervalMinutes)))
+		}
+		setLabels(body, integration.Labels)
+	}
+
+	return nil
+}
+
+func (e *exporter) exportMaintenanceWindows() error {
+	windows, err := e.client.ListMaintenanceWindows()
+	if err != nil {
+		return fmt.Errorf("listing maintenance windows: %w", err)
+	}
+
+	for _, window := range windows {
+		name := e.names.next("towerops_maintenance_window", window.Name, "maintenance_window")
+
+		body := e.resource("maintenance_windows.tf", "towerops_maintenance_window", name, window.ID)
+		body.SetAttributeValue("name", cty.StringVal(window.Name))
+		setString(body, "reason", window.Reason)
+		body.SetAttributeValue("starts_at", cty.StringVal(window.StartsAt))
+		body.SetAttributeValue("ends_at", cty.StringVal(window.EndsAt))
+		if window.SuppressAlerts != nil && !*window.SuppressAlerts {
+			body.SetAttributeValue("suppress_alerts", cty.False)
+		}
+		if window.SiteID != nil {
+			e.reference(body, "site_id", e.sites, "towerops_site", *window.SiteID)
+		}
+		if window.DeviceID != nil {
+			e.reference(body, "device_id", e.devices, "towerops_device", *window.DeviceID)
+		}
+		setLabels(body, window.Labels)
+	}
+
+	return nil
+}
+
+// reference sets attr to the id of the exported resource with the given ID,
+// or to the ID itself when that object was not exported.
+func (e *exporter) reference(body *hclwrite.Body, attr string, exported map[string]string, typ, id string) {
+	name, ok := exported[id]
+	if !ok {
+		body.SetAttributeValue(attr, cty.StringVal(id))
+		return
+	}
+
+	body.SetAttributeTraversal(attr, hcl.Traversal{
+		hcl.TraverseRoot{Name: typ},
+		hcl.TraverseAttr{Name: name},
+		hcl.TraverseAttr{Name: "id"},
+	})
+}
+
+// providerConfig writes provider.tf with the provider requirement and a
+// provider block reading the token from a variable.
+func (e *exporter) providerConfig() {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	requiredProviders := body.AppendNewBlock("terraform", nil).Body().AppendNewBlock("required_providers", nil).Body()
+	requiredProviders.SetAttributeValue("towerops", cty.ObjectVal(map[string]cty.Value{
+		"source": cty.StringVal("towerops/towerops"),
+	}))
+	body.AppendNewline()
+
+	providerBody := body.AppendNewBlock("provider", []string{"towerops"}).Body()
+	providerBody.SetAttributeTraversal("token", hcl.Traversal{
+		hcl.TraverseRoot{Name: "var"},
+		hcl.TraverseAttr{Name: "towerops_api_token"},
+	})
+	if e.client.BaseURL != "" && e.client.BaseURL != provider.DefaultBaseURL {
+		providerBody.SetAttributeValue("api_url", cty.StringVal(e.client.BaseURL))
+	}
+	body.AppendNewline()
+
+	variable := body.AppendNewBlock("variable", []string{"towerops_api_token"}).Body()
+	variable.SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+	variable.SetAttributeValue("sensitive", cty.True)
+
+	e.files["provider.tf"] = f
+	e.order = append([]string{"provider.tf"}, e.order...)
+}
+
+// write formats and writes every generated file to outDir.
+func (e *exporter) write(outDir string) error {
+	files := e.order
+	if e.imports != nil {
+		e.files["imports.tf"] = e.imports
+		files = append(files, "imports.tf")
+	}
+
+	for _, file := range files {
+		if _, err := os.Stat(filepath.Join(outDir, file)); err == nil {
+			return fmt.Errorf("%s already exists", filepath.Join(outDir, file))
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		content := hclwrite.Format(e.files[file].Bytes())
+		if err := os.WriteFile(filepath.Join(outDir, file), content, 0o644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setString(body *hclwrite.Body, attr string, value *string) {
+	if value != nil {
+		body.SetAttributeValue(attr, cty.StringVal(*value))
+	}
+}
+
+func setLabels(body *hclwrite.Body, labels map[string]string) {
+	if len(labels) == 0 {
+		return
+	}
+
+	values := make(map[string]cty.Value, len(labels))
+	for k, v := range labels {
+		values[k] = cty.StringVal(v)
+	}
+	body.SetAttributeValue("labels", cty.MapVal(values))
+}
@@ -0,0 +1,169 @@
+package export
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/towerops/terraform-provider-towerops/internal/provider"
+)
+
+func newExportServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	responses := map[string]string{
+		"/api/v1/organization": `{"data": {"id": "org-1", "name": "ACME Corporation", "slug": "acme", "use_sites": true}}`,
+		"/api/v1/sites": `{"data": [
+			{"id": "site-1", "name": "Main Office", "location": "New York, NY", "labels": {"env": "prod"}},
+			{"id": "site-2", "name": "Main-Office", "snmp_community": "public"}
+		]}`,
+		"/api/v1/devices": `{"data": [
+			{"id": "device-1", "site_id": "site-1", "name": "Core Router", "ip_address": "10.0.0.1", "snmp_version": "2c", "snmp_port": 1161},
+			{"id": "device-2", "site_id": "site-9", "ip_address": "10.0.0.2", "monitoring_enabled": false, "snmpv3_auth_password": "secret"},
+			{"id": "device-3", "site_id": "site-1", "name": "OLT", "ip_address": "olt-1.example.net"},
+			{"id": "device-4", "organization_id": "org-1", "name": "Cloud Router", "ip_address": "10.0.1.1", "snmp_enabled": true, "snmp_version": "2c", "snmp_port": 161}
+		]}`,
+		"/api/v1/schedules":           `{"data": [{"id": "schedule-1", "name": "Primary On-Call", "timezone": "America/Chicago"}]}`,
+		"/api/v1/escalation_policies": `{"data": [{"id": "policy-1", "name": "Critical", "repeat_count": 2}]}`,
+		"/api/v1/agents":              `{"data": [{"id": "agent-1", "name": "Tower Agent"}]}`,
+		"/api/v1/integrations":        `{"data": [{"id": "integration-1", "provider": "pagerduty", "enabled": false}]}`,
+		"/api/v1/maintenance_windows": `{"data": [{"id": "window-1", "name": "Tower climb", "starts_at": "2026-03-02T02:00:00Z", "ends_at": "2026-03-02T06:00:00Z", "device_id": "device-1"}]}`,
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+}
+
+func TestExport(t *testing.T) {
+	server := newExportServer(t)
+	defer server.Close()
+
+	outDir := t.TempDir()
+	var warnings bytes.Buffer
+
+	client := provider.NewClient("test-token", server.URL)
+	if err := Export(client, outDir, &warnings); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	read := func(name string) string {
+		t.Helper()
+		content, err := os.ReadFile(filepath.Join(outDir, name))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return string(content)
+	}
+
+	expected := map[string][]string{
+		"provider.tf": {
+			`source = "towerops/towerops"`,
+			`token   = var.towerops_api_token`,
+			`api_url = "` + server.URL + `"`,
+		},
+		"organization.tf": {
+			`resource "towerops_organization" "this" {`,
+			`use_sites = true`,
+		},
+		"sites.tf": {
+			`resource "towerops_site" "main_office" {`,
+			`resource "towerops_site" "main_office_2" {`,
+			`location = "New York, NY"`,
+			`env = "prod"`,
+		},
+		"devices.tf": {
+			`resource "towerops_device" "core_router" {`,
+			`site_id      = towerops_site.main_office.id`,
+			`snmp_version = "2c"`,
+			`snmp_port    = 1161`,
+			`resource "towerops_device" "device_10_0_0_2" {`,
+			`site_id            = "site-9"`,
+			`monitoring_enabled = false`,
+			`allow_hostname = true`,
+			`resource "towerops_device" "cloud_router" {`,
+			`organization_id = towerops_organization.this.id`,
+			`snmp_enabled    = true`,
+			`snmp_port       = 161`,
+		},
+		"schedules.tf":           {`timezone = "America/Chicago"`},
+		"escalation_policies.tf": {`repeat_count = 2`},
+		"agents.tf":              {`resource "towerops_agent" "tower_agent" {`},
+		"integrations.tf": {
+			`resource "towerops_integration" "pagerduty" {`,
+			`enabled       = false`,
+		},
+		"maintenance_windows.tf": {`device_id = towerops_device.core_router.id`},
+		"imports.tf": {
+			"to = towerops_site.main_office\n  id = \"site-1\"",
+			"to = towerops_device.device_10_0_0_2\n  id = \"device-2\"",
+			"to = towerops_maintenance_window.tower_climb\n  id = \"window-1\"",
+		},
+	}
+
+	for file, snippets := range expected {
+		content := read(file)
+		for _, snippet := range snippets {
+			if !strings.Contains(content, snippet) {
+				t.Errorf("expected %s to contain %q, got:\n%s", file, snippet, content)
+			}
+		}
+	}
+
+	for _, unexpected := range []string{"secret", "public"} {
+		if strings.Contains(read("devices.tf")+read("sites.tf"), unexpected) {
+			t.Errorf("expected %q to be left out of the generated configuration", unexpected)
+		}
+	}
+	if strings.Contains(read("imports.tf"), "towerops_organization") {
+		t.Error("expected no import block for the organization")
+	}
+
+	for _, warning := range []string{"towerops_site.main_office_2", "towerops_device.device_10_0_0_2"} {
+		if !strings.Contains(warnings.String(), warning) {
+			t.Errorf("expected a warning for %s, got:\n%s", warning, warnings.String())
+		}
+	}
+
+	if err := Export(client, outDir, &warnings); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected error for existing files, got: %v", err)
+	}
+}
+
+func TestNamesNext(t *testing.T) {
+	n := make(names)
+
+	tests := []struct {
+		typ, label, want string
+	}{
+		{"towerops_site", "Main Office", "main_office"},
+		{"towerops_site", "  main office!", "main_office_2"},
+		{"towerops_device", "Main Office", "main_office"},
+		{"towerops_device", "10.0.0.1", "device_10_0_0_1"},
+		{"towerops_device", "---", "device"},
+		{"towerops_device", "Überlingen Tower", "berlingen_tower"},
+	}
+
+	for _, tt := range tests {
+		fallback := strings.TrimPrefix(tt.typ, "towerops_")
+		if got := n.next(tt.typ, tt.label, fallback); got != tt.want {
+			t.Errorf("next(%q, %q) = %q, want %q", tt.typ, tt.label, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"fmt"
+	"strings"
+)
+
+// names hands out unique Terraform resource names per resource type.
+type names map[string]map[string]bool
+
+// next returns a resource name for typ derived from label, such as
+// "main_office" for "Main Office". fallback is used when label has no usable
+// characters and as a prefix when it starts with a digit. Repeated names get
+// a numeric suffix.
+func (n names) next(typ, label, fallback string) string {
+	base := identifier(label)
+	switch {
+	case base == "":
+		base = fallback
+	case base[0] >= '0' && base[0] <= '9':
+		base = fallback + "_" + base
+	}
+
+	if n[typ] == nil {
+		n[typ] = make(map[string]bool)
+	}
+
+	name := base
+	for i := 2; n[typ][name]; i++ {
+		name = fmt.Sprintf("%s_%d", base, i)
+	}
+	n[typ][name] = true
+
+	return name
+}
+
+// identifier lowercases s and replaces every run of characters other than
+// letters and digits with a single underscore.
+func identifier(s string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		underscore = true
+	}
+	return b.String()
+}
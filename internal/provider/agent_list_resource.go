@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &AgentListResource{}
+var _ list.ListResourceWithConfigure = &AgentListResource{}
+
+// AgentListResource lists existing agents for terraform query.
+type AgentListResource struct {
+	client *Client
+}
+
+// AgentListResourceModel describes the list filters.
+type AgentListResourceModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+}
+
+// NewAgentListResource creates a new agent list resource.
+func NewAgentListResource() list.ListResource {
+	return &AgentListResource{}
+}
+
+func (r *AgentListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent"
+}
+
+func (r *AgentListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing TowerOps agents.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Description: "Only list agents whose name starts with this prefix.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *AgentListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AgentListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config AgentListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	agents, err := r.client.ListAgents()
+	if err != nil {
+		stream.Results = listErrorStream("Failed to list agents", err.Error())
+		return
+	}
+
+	var matched []Agent
+	for _, agent := range agents {
+		if !strings.HasPrefix(agent.Name, config.NamePrefix.ValueString()) {
+			continue
+		}
+		matched = append(matched, agent)
+	}
+
+	stream.Results = streamListResults(ctx, r.client, req, matched,
+		func(agent Agent) string { return agent.ID },
+		func(agent Agent, result *list.ListResult) {
+			result.DisplayName = agent.Name
+			if !req.IncludeResource {
+				return
+			}
+
+			// The token is only returned on creation, so it is left null.
+			data := AgentResourceModel{
+				ID:         types.StringValue(agent.ID),
+				Name:       types.StringValue(agent.Name),
+				InsertedAt: NewTimestampValue(agent.InsertedAt),
+			}
+
+			var d diag.Diagnostics
+			data.Labels, data.LabelsAll, d = labelsForImport(ctx, r.client, agent.Labels)
+			result.Diagnostics.Append(d...)
+
+			result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+		},
+	)
+}
@@ -2,9 +2,12 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,7 +17,10 @@ import (
 )
 
 var _ resource.Resource = &AgentResource{}
+var _ resource.ResourceWithUpgradeState = &AgentResource{}
 var _ resource.ResourceWithImportState = &AgentResource{}
+var _ resource.ResourceWithIdentity = &AgentResource{}
+var _ resource.ResourceWithModifyPlan = &AgentResource{}
 
 // AgentResource defines the resource implementation.
 type AgentResource struct {
@@ -23,10 +29,13 @@ type AgentResource struct {
 
 // AgentResourceModel describes the resource data model.
 type AgentResourceModel struct {
-	ID         types.String `tfsdk:"id"`
-	Name       types.String `tfsdk:"name"`
-	Token      types.String `tfsdk:"token"`
-	InsertedAt types.String `tfsdk:"inserted_at"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Token              types.String   `tfsdk:"token"`
+	Labels             types.Map      `tfsdk:"labels"`
+	LabelsAll          types.Map      `tfsdk:"labels_all"`
+	InsertedAt         TimestampValue `tfsdk:"inserted_at"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
 }
 
 // NewAgentResource creates a new agent resource.
@@ -38,8 +47,13 @@ func (r *AgentResource) Metadata(ctx context.Context, req resource.MetadataReque
 	resp.TypeName = req.ProviderTypeName + "_agent"
 }
 
+func (r *AgentResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema()
+}
+
 func (r *AgentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages a TowerOps agent token. Agents are deployed on customer networks to poll devices via SNMP, ping, and SSH.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -64,7 +78,11 @@ func (r *AgentResource) Schema(ctx context.Context, req resource.SchemaRequest,
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"deletion_protection": deletionProtectionAttribute(),
+			"labels":              labelsAttribute(),
+			"labels_all":          labelsAllAttribute(),
 			"inserted_at": schema.StringAttribute{
+				CustomType:  TimestampType{},
 				Description: "The timestamp when the agent was created.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
@@ -75,6 +93,12 @@ func (r *AgentResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+func (r *AgentResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r,
+		upgradeFromV0,
+	)
+}
+
 func (r *AgentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -92,6 +116,109 @@ func (r *AgentResource) Configure(ctx context.Context, req resource.ConfigureReq
 	r.client = client
 }
 
+func (r *AgentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanDeletionProtection(ctx, r.client, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modifyPlanLabels(ctx, r.client, req, resp)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// Agents cannot be updated, so a change to the agent's own labels
+	// replaces it. A change to default_labels alone must not replace every
+	// agent and rotate its token, so existing agents keep their labels_all.
+	var plannedLabels, currentLabels, plannedAll, currentAll types.Map
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("labels"), &plannedLabels)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("labels"), &currentLabels)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("labels_all"), &plannedAll)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("labels_all"), &currentAll)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plannedLabels.Equal(currentLabels) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("labels"))
+		return
+	}
+
+	if plannedAll.Equal(currentAll) {
+		return
+	}
+
+	// Only a change to default_labels is kept out of the plan. If labels_all
+	// no longer matches what the agent was created with, it was edited
+	// outside Terraform and replacing the agent is the only way back.
+	created, diags := agentCreatedLabels(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	current, diags := labelsFromModel(ctx, currentAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if created == nil || !maps.Equal(created, current) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("labels_all"))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), currentAll)...)
+	if !plannedAll.IsUnknown() {
+		resp.Diagnostics.AddWarning(
+			"Default Labels Not Applied to Existing Agent",
+			"Agents cannot be updated in place, so changes to the provider's default_labels only apply to agents created from now on. To apply them to this agent, replace it with terraform apply -replace, which also issues a new token.",
+		)
+	}
+}
+
+// agentCreatedLabelsKey is the private state key holding the labels_all an
+// agent was created with.
+const agentCreatedLabelsKey = "created_labels"
+
+// privateStateGetter is the read side of resource private state.
+type privateStateGetter interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+}
+
+// privateStateSetter is the write side of resource private state.
+type privateStateSetter interface {
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// agentCreatedLabels returns the labels_all recorded for the agent, or nil
+// if none were recorded.
+func agentCreatedLabels(ctx context.Context, private privateStateGetter) (map[string]string, diag.Diagnostics) {
+	raw, diags := private.GetKey(ctx, agentCreatedLabelsKey)
+	if diags.HasError() || raw == nil {
+		return nil, diags
+	}
+
+	labels := make(map[string]string)
+	if err := json.Unmarshal(raw, &labels); err != nil {
+		diags.AddError("Failed to read agent labels from private state", err.Error())
+		return nil, diags
+	}
+	return labels, diags
+}
+
+// setAgentCreatedLabels records labelsAll as the labels the agent was
+// created with.
+func setAgentCreatedLabels(ctx context.Context, private privateStateSetter, labelsAll types.Map) diag.Diagnostics {
+	labels, diags := labelsFromModel(ctx, labelsAll)
+	if diags.HasError() {
+		return diags
+	}
+
+	raw, err := json.Marshal(labels)
+	if err != nil {
+		diags.AddError("Failed to record agent labels in private state", err.Error())
+		return diags
+	}
+	diags.Append(private.SetKey(ctx, agentCreatedLabelsKey, raw)...)
+	return diags
+}
+
 func (r *AgentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data AgentResourceModel
 
@@ -104,6 +231,13 @@ func (r *AgentResource) Create(ctx context.Context, req resource.CreateRequest,
 		Name: data.Name.ValueString(),
 	}
 
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	agent.Labels = labels
+
 	created, err := r.client.CreateAgent(agent)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create agent", err.Error())
@@ -112,9 +246,12 @@ func (r *AgentResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	data.ID = types.StringValue(created.ID)
 	data.Token = types.StringValue(created.Token)
-	data.InsertedAt = types.StringValue(created.InsertedAt)
+	data.InsertedAt = NewTimestampValue(created.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, created.Labels)...)
+	resp.Diagnostics.Append(setAgentCreatedLabels(ctx, resp.Private, data.LabelsAll)...)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *AgentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -136,21 +273,38 @@ func (r *AgentResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	data.Name = types.StringValue(agent.Name)
-	data.InsertedAt = types.StringValue(agent.InsertedAt)
+	data.InsertedAt = NewTimestampValue(agent.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, agent.Labels)...)
 	// Token is not returned by GET, preserve existing state value
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(defaultDeletionProtection(r.client))
+	}
+
+	// Agents imported or created before the labels were recorded take the
+	// labels they have now as their baseline.
+	created, diags := agentCreatedLabels(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
+	if created == nil {
+		resp.Diagnostics.Append(setAgentCreatedLabels(ctx, resp.Private, data.LabelsAll)...)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *AgentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Agents only support name changes via the API, but the REST API doesn't have
-	// an update endpoint. For now, if name changes, we must destroy and recreate.
-	// This is handled by Terraform's ForceNew-like behavior since all mutable
-	// attributes are required.
-	resp.Diagnostics.AddError(
-		"Agent Update Not Supported",
-		"Agent tokens cannot be updated. Delete and recreate the agent to change its name.",
-	)
+	// The REST API has no update endpoint, so every attribute sent to the API
+	// forces a new agent. The only in-place change is to deletion_protection,
+	// which lives in Terraform state alone.
+	var data AgentResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *AgentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -161,6 +315,11 @@ func (r *AgentResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(checkDeletionProtection(data.DeletionProtection, "agent", data.ID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteAgent(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to delete agent", err.Error())
@@ -169,5 +328,5 @@ func (r *AgentResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *AgentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithIdentity(ctx, r.client, req, resp)
 }
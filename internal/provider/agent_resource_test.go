@@ -0,0 +1,219 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAgentResource_ModifyPlan_labels(t *testing.T) {
+	ctx := context.Background()
+
+	labelsMap := func(labels map[string]string) tftypes.Value {
+		elements := make(map[string]tftypes.Value, len(labels))
+		for k, v := range labels {
+			elements[k] = tfString(v)
+		}
+		return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, elements)
+	}
+	private := func(labels map[string]string) []byte {
+		raw, err := json.Marshal(labels)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, err := json.Marshal(map[string][]byte{agentCreatedLabelsKey: raw})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return data
+	}
+
+	tests := []struct {
+		name          string
+		defaultLabels map[string]string
+		labels        map[string]string
+		labelsAll     map[string]string
+		created       map[string]string
+		wantReplace   string
+		wantWarning   bool
+		wantLabelsAll map[string]string
+	}{
+		{
+			name:          "unchanged",
+			defaultLabels: map[string]string{"team": "noc"},
+			labels:        map[string]string{"site": "tower-a"},
+			created:       map[string]string{"site": "tower-a", "team": "noc"},
+			wantLabelsAll: map[string]string{"site": "tower-a", "team": "noc"},
+		},
+		{
+			name:          "default labels changed",
+			defaultLabels: map[string]string{"team": "core"},
+			labels:        map[string]string{"site": "tower-a"},
+			created:       map[string]string{"site": "tower-a", "team": "noc"},
+			wantWarning:   true,
+			wantLabelsAll: map[string]string{"site": "tower-a", "team": "noc"},
+		},
+		{
+			name:          "labels changed",
+			defaultLabels: map[string]string{"team": "noc"},
+			labels:        map[string]string{"site": "tower-b"},
+			created:       map[string]string{"site": "tower-a", "team": "noc"},
+			wantReplace:   "labels",
+		},
+		{
+			name:          "labels_all edited outside terraform",
+			defaultLabels: map[string]string{"team": "noc"},
+			labels:        map[string]string{"site": "tower-a"},
+			labelsAll:     map[string]string{"site": "tower-a", "team": "support"},
+			created:       map[string]string{"site": "tower-a", "team": "noc"},
+			wantReplace:   "labels_all",
+		},
+		{
+			name:          "labels_all not recorded",
+			defaultLabels: map[string]string{"team": "core"},
+			labels:        map[string]string{"site": "tower-a"},
+			wantReplace:   "labels_all",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := make(map[string]tftypes.Value, len(tt.defaultLabels))
+			for k, v := range tt.defaultLabels {
+				defaults[k] = tfString(v)
+			}
+			server, schemaResp := newConfiguredProviderServer(t, "http://localhost", map[string]tftypes.Value{
+				"default_labels": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, defaults),
+			})
+			objectType := schemaResp.ResourceSchemas["towerops_agent"].ValueType().(tftypes.Object)
+
+			labelsAll := tt.labelsAll
+			if labelsAll == nil {
+				labelsAll = map[string]string{"site": "tower-a", "team": "noc"}
+			}
+			prior := map[string]tftypes.Value{
+				"id":                  tfString("agent-123"),
+				"name":                tfString("Tower Agent"),
+				"token":               tfString("agent-token"),
+				"labels":              labelsMap(map[string]string{"site": "tower-a"}),
+				"labels_all":          labelsMap(labelsAll),
+				"inserted_at":         tfString("2024-01-01T00:00:00Z"),
+				"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+			}
+			proposed := make(map[string]tftypes.Value, len(prior))
+			for k, v := range prior {
+				proposed[k] = v
+			}
+			proposed["labels"] = labelsMap(tt.labels)
+
+			var priorPrivate []byte
+			if tt.created != nil {
+				priorPrivate = private(tt.created)
+			}
+
+			resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "towerops_agent",
+				PriorState:       newDynamicValue(t, objectType, prior),
+				ProposedNewState: newDynamicValue(t, objectType, proposed),
+				Config: newDynamicValue(t, objectType, map[string]tftypes.Value{
+					"name":   tfString("Tower Agent"),
+					"labels": labelsMap(tt.labels),
+				}),
+				PriorPrivate: priorPrivate,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			warned := false
+			for _, d := range resp.Diagnostics {
+				switch d.Severity {
+				case tfprotov6.DiagnosticSeverityError:
+					t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+				case tfprotov6.DiagnosticSeverityWarning:
+					warned = true
+				}
+			}
+			if warned != tt.wantWarning {
+				t.Errorf("expected warning %t, got: %v", tt.wantWarning, resp.Diagnostics)
+			}
+
+			var replace []string
+			for _, p := range resp.RequiresReplace {
+				replace = append(replace, p.String())
+			}
+			if tt.wantReplace == "" {
+				if len(replace) > 0 {
+					t.Errorf("expected no replacement, got RequiresReplace %v", replace)
+				}
+			} else if len(replace) != 1 || replace[0] != `AttributeName("`+tt.wantReplace+`")` {
+				t.Errorf("expected %s to require replacement, got %v", tt.wantReplace, replace)
+			}
+
+			if tt.wantLabelsAll == nil {
+				return
+			}
+			planned, err := resp.PlannedState.Unmarshal(objectType)
+			if err != nil {
+				t.Fatalf("unexpected error decoding plan: %v", err)
+			}
+			var attrs map[string]tftypes.Value
+			if err := planned.As(&attrs); err != nil {
+				t.Fatalf("unexpected error decoding plan: %v", err)
+			}
+			if !attrs["labels_all"].Equal(labelsMap(tt.wantLabelsAll)) {
+				t.Errorf("expected labels_all %v, got %s", tt.wantLabelsAll, attrs["labels_all"])
+			}
+		})
+	}
+}
+
+func TestAgentResource_Read_recordsLabels(t *testing.T) {
+	ctx := context.Background()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/agents/agent-123":
+			w.Write([]byte(`{"id": "agent-123", "name": "Tower Agent", "labels": {"team": "support"}, "inserted_at": "2024-01-01T00:00:00Z"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/organization":
+			w.Write([]byte(`{"data": {"id": "org-123", "name": "Test ISP"}}`))
+		default:
+			t.Errorf("unexpected %s request to %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer apiServer.Close()
+
+	server, schemaResp := newConfiguredProviderServer(t, apiServer.URL, nil)
+	objectType := schemaResp.ResourceSchemas["towerops_agent"].ValueType().(tftypes.Object)
+
+	resp, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName: "towerops_agent",
+		CurrentState: newDynamicValue(t, objectType, map[string]tftypes.Value{
+			"id":    tfString("agent-123"),
+			"name":  tfString("Tower Agent"),
+			"token": tfString("agent-token"),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	var private map[string][]byte
+	if err := json.Unmarshal(resp.Private, &private); err != nil {
+		t.Fatalf("unexpected error decoding private state: %v", err)
+	}
+	if got := string(private[agentCreatedLabelsKey]); got != `{"team":"support"}` {
+		t.Errorf("expected the API labels to be recorded, got %q", got)
+	}
+}
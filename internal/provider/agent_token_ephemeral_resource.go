@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &AgentTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &AgentTokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &AgentTokenEphemeralResource{}
+
+// agentTokenPrivateKey is the private data key holding the agent and token
+// IDs that Close revokes.
+const agentTokenPrivateKey = "agent_token"
+
+// agentTokenPrivate identifies an issued agent token in private data.
+type agentTokenPrivate struct {
+	AgentID string `json:"agent_id"`
+	TokenID string `json:"token_id"`
+}
+
+// AgentTokenEphemeralResource issues agent tokens that are never written to
+// plan or state. Each token is revoked when Terraform closes the resource, so
+// one is only valid for the run that issued it.
+type AgentTokenEphemeralResource struct {
+	client *Client
+}
+
+// AgentTokenEphemeralResourceModel describes the ephemeral resource data model.
+type AgentTokenEphemeralResourceModel struct {
+	AgentID   types.String   `tfsdk:"agent_id"`
+	Token     types.String   `tfsdk:"token"`
+	ExpiresAt TimestampValue `tfsdk:"expires_at"`
+}
+
+// NewAgentTokenEphemeralResource creates a new agent token ephemeral resource.
+func NewAgentTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &AgentTokenEphemeralResource{}
+}
+
+func (r *AgentTokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_agent_token"
+}
+
+func (r *AgentTokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Issues a short-lived bearer token for a TowerOps agent, for example to enroll an agent from a provisioner or a deployment provider during the run. Terraform opens the resource during both plan and apply, and a new token is issued each time. Each token is revoked when Terraform closes the resource at the end of the run and is never persisted in plan or state, so it cannot be stored for later use. Use the token attribute of towerops_agent for the agent's long-lived credential. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"agent_id": schema.StringAttribute{
+				Description: "The ID of the agent to issue a token for.",
+				Required:    true,
+			},
+			"token": schema.StringAttribute{
+				Description: "The bearer token for the agent.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				CustomType:  TimestampType{},
+				Description: "The timestamp when the token expires, if the API sets one.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *AgentTokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *AgentTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data AgentTokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	token, err := r.client.CreateAgentToken(data.AgentID.ValueString())
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("agent_id"),
+				"Agent Not Found",
+				fmt.Sprintf("No agent exists with ID %q.", data.AgentID.ValueString()),
+			)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to create agent token", err.Error())
+		return
+	}
+
+	data.Token = types.StringValue(token.Token)
+	data.ExpiresAt = NewTimestampPointerValue(token.ExpiresAt)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	private, err := json.Marshal(agentTokenPrivate{AgentID: data.AgentID.ValueString(), TokenID: token.ID})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to record agent token", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, agentTokenPrivateKey, private)...)
+}
+
+func (r *AgentTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, agentTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+
+	var private agentTokenPrivate
+	if err := json.Unmarshal(raw, &private); err != nil {
+		resp.Diagnostics.AddError("Failed to read agent token", err.Error())
+		return
+	}
+	if private.TokenID == "" {
+		resp.Diagnostics.AddWarning(
+			"Agent Token Not Revoked",
+			fmt.Sprintf("The API did not return an ID for the token issued to agent %q, so it cannot be revoked and stays valid until it expires.", private.AgentID),
+		)
+		return
+	}
+
+	err := r.client.RevokeAgentToken(private.AgentID, private.TokenID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError("Failed to revoke agent token", err.Error())
+	}
+}
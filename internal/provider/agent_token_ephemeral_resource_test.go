@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAgentTokenEphemeralResource_Open(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/agents/agent-123/token":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "tok-1", "token": "agt_secret", "expires_at": "2024-01-02T00:00:00Z"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/agents/agent-456/token":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"token": "agt_forever"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/agents/agent-missing/token":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": "not found"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		readOnly      bool
+		agentID       string
+		wantErr       string
+		wantToken     string
+		wantExpiresAt any
+	}{
+		{name: "token with expiry", agentID: "agent-123", wantToken: "agt_secret", wantExpiresAt: "2024-01-02T00:00:00Z"},
+		{name: "token without expiry", agentID: "agent-456", wantToken: "agt_forever"},
+		{name: "agent not found", agentID: "agent-missing", wantErr: "Agent Not Found"},
+		{name: "read only", readOnly: true, agentID: "agent-unsent", wantErr: "Failed to create agent token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			provider, schemaResp := newConfiguredProviderServer(t, server.URL, map[string]tftypes.Value{
+				"read_only": tftypes.NewValue(tftypes.Bool, tt.readOnly),
+			})
+			objectType := schemaResp.EphemeralResourceSchemas["towerops_agent_token"].ValueType().(tftypes.Object)
+
+			resp, err := provider.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+				TypeName: "towerops_agent_token",
+				Config:   newDynamicValue(t, objectType, map[string]tftypes.Value{"agent_id": tfString(tt.agentID)}),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var errs []string
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					errs = append(errs, d.Summary)
+				}
+			}
+
+			if tt.wantErr != "" {
+				if len(errs) != 1 || errs[0] != tt.wantErr {
+					t.Errorf("expected error %q, got: %v", tt.wantErr, errs)
+				}
+				return
+			}
+			if len(errs) > 0 {
+				t.Fatalf("unexpected errors: %v", errs)
+			}
+
+			result, err := resp.Result.Unmarshal(objectType)
+			if err != nil {
+				t.Fatalf("unexpected error decoding result: %v", err)
+			}
+			var values map[string]tftypes.Value
+			if err := result.As(&values); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for name, want := range map[string]any{
+				"agent_id":   tt.agentID,
+				"token":      tt.wantToken,
+				"expires_at": tt.wantExpiresAt,
+			} {
+				if got := values[name]; !got.Equal(tftypes.NewValue(tftypes.String, want)) {
+					t.Errorf("expected %s %v, got %s", name, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestAgentTokenEphemeralResource_Close(t *testing.T) {
+	var revoked []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/agents/agent-123/token":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id": "tok-1", "token": "agt_secret"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/agents/agent-456/token":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"token": "agt_forever"}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/agents/agent-123/token/tok-1":
+			revoked = append(revoked, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		agentID     string
+		wantRevoked []string
+		wantWarning string
+	}{
+		{name: "revoked", agentID: "agent-123", wantRevoked: []string{"/api/v1/agents/agent-123/token/tok-1"}},
+		{name: "no token id", agentID: "agent-456", wantWarning: "Agent Token Not Revoked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			revoked = nil
+
+			provider, schemaResp := newConfiguredProviderServer(t, server.URL, nil)
+			objectType := schemaResp.EphemeralResourceSchemas["towerops_agent_token"].ValueType().(tftypes.Object)
+
+			openResp, err := provider.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+				TypeName: "towerops_agent_token",
+				Config:   newDynamicValue(t, objectType, map[string]tftypes.Value{"agent_id": tfString(tt.agentID)}),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(openResp.Diagnostics) > 0 {
+				t.Fatalf("unexpected diagnostics: %v", openResp.Diagnostics)
+			}
+
+			closeResp, err := provider.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+				TypeName: "towerops_agent_token",
+				Private:  openResp.Private,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var warnings []string
+			for _, d := range closeResp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+				}
+				warnings = append(warnings, d.Summary)
+			}
+			if tt.wantWarning != "" && (len(warnings) != 1 || warnings[0] != tt.wantWarning) {
+				t.Errorf("expected warning %q, got: %v", tt.wantWarning, warnings)
+			}
+			if tt.wantWarning == "" && len(warnings) > 0 {
+				t.Errorf("unexpected warnings: %v", warnings)
+			}
+			if !slices.Equal(revoked, tt.wantRevoked) {
+				t.Errorf("expected revoked %v, got %v", tt.wantRevoked, revoked)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ ephemeral.EphemeralResource = &APITokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &APITokenEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithClose = &APITokenEphemeralResource{}
+
+// apiTokenPrivateKey is the private data key holding the ID of the token
+// that Close revokes.
+const apiTokenPrivateKey = "api_token_id"
+
+// APITokenEphemeralResource exchanges the provider token for a scoped,
+// short-lived API token that is never written to plan or state. The token is
+// revoked when Terraform closes the resource.
+type APITokenEphemeralResource struct {
+	client *Client
+}
+
+// APITokenEphemeralResourceModel describes the ephemeral resource data model.
+type APITokenEphemeralResourceModel struct {
+	Scopes      types.List     `tfsdk:"scopes"`
+	TTLSeconds  types.Int64    `tfsdk:"ttl_seconds"`
+	Description types.String   `tfsdk:"description"`
+	ID          types.String   `tfsdk:"id"`
+	Token       types.String   `tfsdk:"token"`
+	ExpiresAt   TimestampValue `tfsdk:"expires_at"`
+}
+
+// NewAPITokenEphemeralResource creates a new API token ephemeral resource.
+func NewAPITokenEphemeralResource() ephemeral.EphemeralResource {
+	return &APITokenEphemeralResource{}
+}
+
+func (r *APITokenEphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (r *APITokenEphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exchanges the provider token for a scoped, short-lived TowerOps API token. The token is only available to other ephemeral contexts such as provider blocks and provisioners, and is never persisted in plan or state. Terraform opens the resource during both plan and apply, and a new token is issued each time. Each token is revoked when Terraform closes the resource at the end of the run. Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"scopes": schema.ListAttribute{
+				Description: "The scopes granted to the token (e.g. devices:read, sites:read). At least one scope is required.",
+				ElementType: types.StringType,
+				Required:    true,
+				Validators:  []validator.List{listvalidator.SizeAtLeast(1)},
+			},
+			"ttl_seconds": schema.Int64Attribute{
+				Description: "How long the token remains valid, in seconds. If omitted, the API's default lifetime applies.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"description": schema.StringAttribute{
+				Description: "A description recorded with the token, shown in the TowerOps audit log.",
+				Optional:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The unique identifier of the token.",
+				Computed:    true,
+			},
+			"token": schema.StringAttribute{
+				Description: "The scoped bearer token.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"expires_at": schema.StringAttribute{
+				CustomType:  TimestampType{},
+				Description: "The timestamp when the token expires.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *APITokenEphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *APITokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data APITokenEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var scopes []string
+	resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &scopes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokenReq := APITokenRequest{
+		Scopes: scopes,
+	}
+
+	if !data.TTLSeconds.IsNull() {
+		ttl := int(data.TTLSeconds.ValueInt64())
+		tokenReq.TTLSeconds = &ttl
+	}
+
+	if !data.Description.IsNull() {
+		desc := data.Description.ValueString()
+		tokenReq.Description = &desc
+	}
+
+	token, err := r.client.CreateAPIToken(tokenReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to create API token", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(token.ID)
+	data.Token = types.StringValue(token.Token)
+	data.ExpiresAt = NewTimestampValue(token.ExpiresAt)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	id, err := json.Marshal(token.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to record API token", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, apiTokenPrivateKey, id)...)
+}
+
+func (r *APITokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, apiTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+
+	var id string
+	if err := json.Unmarshal(raw, &id); err != nil {
+		resp.Diagnostics.AddError("Failed to read API token", err.Error())
+		return
+	}
+	if id == "" {
+		resp.Diagnostics.AddWarning(
+			"API Token Not Revoked",
+			"The API did not return an ID for the issued token, so it cannot be revoked and stays valid until it expires.",
+		)
+		return
+	}
+
+	err := r.client.RevokeAPIToken(id)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		resp.Diagnostics.AddError("Failed to revoke API token", err.Error())
+	}
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestAPITokenEphemeralResource_Open(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/api_tokens" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var body map[string]APITokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode body: %v", err)
+		}
+		token := body["api_token"]
+		if !slices.Equal(token.Scopes, []string{"devices:read", "sites:read"}) {
+			t.Errorf("expected scopes devices:read, sites:read, got %v", token.Scopes)
+		}
+		if token.TTLSeconds == nil || *token.TTLSeconds != 900 {
+			t.Errorf("expected ttl_seconds 900, got %v", token.TTLSeconds)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{
+			"id": "token-123",
+			"token": "tok_scoped",
+			"scopes": ["devices:read", "sites:read"],
+			"expires_at": "2024-01-01T00:15:00Z"
+		}`))
+	}))
+	defer server.Close()
+
+	provider, schemaResp := newConfiguredProviderServer(t, server.URL, nil)
+	objectType := schemaResp.EphemeralResourceSchemas["towerops_api_token"].ValueType().(tftypes.Object)
+
+	resp, err := provider.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: "towerops_api_token",
+		Config: newDynamicValue(t, objectType, map[string]tftypes.Value{
+			"scopes": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tfString("devices:read"),
+				tfString("sites:read"),
+			}),
+			"ttl_seconds": tftypes.NewValue(tftypes.Number, 900),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	result, err := resp.Result.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("unexpected error decoding result: %v", err)
+	}
+	var values map[string]tftypes.Value
+	if err := result.As(&values); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, want := range map[string]string{
+		"id":         "token-123",
+		"token":      "tok_scoped",
+		"expires_at": "2024-01-01T00:15:00Z",
+	} {
+		if got := values[name]; !got.Equal(tfString(want)) {
+			t.Errorf("expected %s %s, got %s", name, want, got)
+		}
+	}
+	if got := values["description"]; !got.IsNull() {
+		t.Errorf("expected description to stay null, got %s", got)
+	}
+}
+
+func TestAPITokenEphemeralResource_Close(t *testing.T) {
+	var revoked []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/api_tokens":
+			var body map[string]APITokenRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("failed to decode body: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			if body["api_token"].Scopes[0] == "sites:read" {
+				w.Write([]byte(`{"token": "tok_unnamed", "scopes": ["sites:read"]}`))
+				return
+			}
+			w.Write([]byte(`{"id": "token-123", "token": "tok_scoped", "scopes": ["devices:read"]}`))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/api_tokens/token-123":
+			revoked = append(revoked, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name        string
+		scope       string
+		wantRevoked []string
+		wantWarning string
+	}{
+		{name: "revoked", scope: "devices:read", wantRevoked: []string{"/api/v1/api_tokens/token-123"}},
+		{name: "no token id", scope: "sites:read", wantWarning: "API Token Not Revoked"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			revoked = nil
+
+			provider, schemaResp := newConfiguredProviderServer(t, server.URL, nil)
+			objectType := schemaResp.EphemeralResourceSchemas["towerops_api_token"].ValueType().(tftypes.Object)
+
+			openResp, err := provider.OpenEphemeralResource(ctx, &tfprotov6.OpenEphemeralResourceRequest{
+				TypeName: "towerops_api_token",
+				Config: newDynamicValue(t, objectType, map[string]tftypes.Value{
+					"scopes": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{tfString(tt.scope)}),
+				}),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(openResp.Diagnostics) > 0 {
+				t.Fatalf("unexpected diagnostics: %v", openResp.Diagnostics)
+			}
+
+			closeResp, err := provider.CloseEphemeralResource(ctx, &tfprotov6.CloseEphemeralResourceRequest{
+				TypeName: "towerops_api_token",
+				Private:  openResp.Private,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var warnings []string
+			for _, d := range closeResp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+				}
+				warnings = append(warnings, d.Summary)
+			}
+			if tt.wantWarning != "" && (len(warnings) != 1 || warnings[0] != tt.wantWarning) {
+				t.Errorf("expected warning %q, got: %v", tt.wantWarning, warnings)
+			}
+			if tt.wantWarning == "" && len(warnings) > 0 {
+				t.Errorf("unexpected warnings: %v", warnings)
+			}
+			if !slices.Equal(revoked, tt.wantRevoked) {
+				t.Errorf("expected revoked %v, got %v", tt.wantRevoked, revoked)
+			}
+		})
+	}
+}
+
+func TestAPITokenEphemeralResource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+
+	provider, schemaResp := newConfiguredProviderServer(t, "http://localhost", nil)
+	objectType := schemaResp.EphemeralResourceSchemas["towerops_api_token"].ValueType().(tftypes.Object)
+	scopes := func(values ...tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, values)
+	}
+
+	tests := []struct {
+		name    string
+		attrs   map[string]tftypes.Value
+		wantErr bool
+	}{
+		{name: "scopes only", attrs: map[string]tftypes.Value{"scopes": scopes(tfString("devices:read"))}},
+		{name: "no scopes", attrs: map[string]tftypes.Value{"scopes": scopes()}, wantErr: true},
+		{
+			name: "positive ttl",
+			attrs: map[string]tftypes.Value{
+				"scopes":      scopes(tfString("devices:read")),
+				"ttl_seconds": tftypes.NewValue(tftypes.Number, 1),
+			},
+		},
+		{
+			name: "zero ttl",
+			attrs: map[string]tftypes.Value{
+				"scopes":      scopes(tfString("devices:read")),
+				"ttl_seconds": tftypes.NewValue(tftypes.Number, 0),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := provider.ValidateEphemeralResourceConfig(ctx, &tfprotov6.ValidateEphemeralResourceConfigRequest{
+				TypeName: "towerops_api_token",
+				Config:   newDynamicValue(t, objectType, tt.attrs),
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var errs []string
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					errs = append(errs, d.Summary)
+				}
+			}
+			if tt.wantErr && len(errs) == 0 {
+				t.Error("expected an error, got none")
+			}
+			if !tt.wantErr && len(errs) > 0 {
+				t.Errorf("unexpected errors: %v", errs)
+			}
+		})
+	}
+}
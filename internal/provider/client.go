@@ -6,26 +6,90 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
 )
 
 // ErrNotFound is returned when a resource is not found (404).
 var ErrNotFound = errors.New("resource not found")
 
-const defaultBaseURL = "https://towerops.net"
+// ErrUnauthorized is returned when the API rejects the token (401).
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrForbidden is returned when the token lacks permission for a request (403).
+var ErrForbidden = errors.New("forbidden")
+
+// ErrConflict is returned when the API refuses a request that conflicts with
+// the current state of the object (409).
+var ErrConflict = errors.New("conflict")
+
+// ErrReadOnly is returned when a mutating request is attempted while the
+// client is in read-only mode.
+var ErrReadOnly = errors.New("provider is in read-only mode")
+
+// DefaultBaseURL is the TowerOps API used when no api_url is configured.
+const DefaultBaseURL = "https://towerops.net"
 
 // Client is the TowerOps API client.
 type Client struct {
 	BaseURL    string
 	Token      string
 	HTTPClient *http.Client
+	// ReadOnly rejects every non-GET request before it is sent.
+	ReadOnly bool
+	// DefaultLabels are merged into the labels of every labelled resource.
+	DefaultLabels map[string]string
+	// DeviceDefaults are applied to devices that don't set these values.
+	DeviceDefaults DeviceDefaults
+	// DeletionProtection is the default deletion_protection for sites,
+	// devices and agents.
+	DeletionProtection bool
+	// MaintenanceWindowMaxDuration is the longest maintenance window allowed.
+	// Zero means the default of 30 days.
+	MaintenanceWindowMaxDuration time.Duration
+
+	orgMu sync.Mutex
+	org   *Organization
+	// plannedUseSites is the use_sites value a towerops_organization plan
+	// in this run changes to. Nil when no plan has recorded one.
+	plannedUseSites *UseSitesPlan
+	// organizationManaged is set once a towerops_organization has been
+	// validated, read or planned in this run.
+	organizationManaged bool
+}
+
+// UseSitesPlan is a use_sites value planned by towerops_organization.
+type UseSitesPlan struct {
+	// Known is false when the value is only known after apply.
+	Known bool
+	Value bool
+	// Changing is true when the plan changes the organization's current
+	// setting.
+	Changing bool
+}
+
+// DeviceDefaults holds provider-level device settings. A nil field means no
+// default is configured.
+type DeviceDefaults struct {
+	MonitoringEnabled   *bool
+	SNMPEnabled         *bool
+	SNMPVersion         *string
+	SNMPPort            *int64
+	SNMPv3SecurityLevel *string
+	SNMPv3Username      *string
+	SNMPv3AuthProtocol  *string
+	SNMPv3AuthPassword  *string
+	SNMPv3PrivProtocol  *string
+	SNMPv3PrivPassword  *string
 }
 
 // NewClient creates a new TowerOps API client.
 func NewClient(token, baseURL string) *Client {
 	if baseURL == "" {
-		baseURL = defaultBaseURL
+		baseURL = DefaultBaseURL
 	}
 	return &Client{
 		BaseURL: baseURL,
@@ -38,14 +102,15 @@ func NewClient(token, baseURL string) *Client {
 
 // Site represents a TowerOps site.
 type Site struct {
-	ID            string   `json:"id,omitempty"`
-	Name          string   `json:"name"`
-	Location      *string  `json:"location,omitempty"`
-	Address       *string  `json:"address,omitempty"`
-	Latitude      *float64 `json:"latitude,omitempty"`
-	Longitude     *float64 `json:"longitude,omitempty"`
-	SNMPCommunity *string  `json:"snmp_community,omitempty"`
-	InsertedAt    string   `json:"inserted_at,omitempty"`
+	ID            string            `json:"id,omitempty"`
+	Name          string            `json:"name"`
+	Location      *string           `json:"location,omitempty"`
+	Address       *string           `json:"address,omitempty"`
+	Latitude      *float64          `json:"latitude,omitempty"`
+	Longitude     *float64          `json:"longitude,omitempty"`
+	SNMPCommunity *string           `json:"snmp_community,omitempty"`
+	Labels        map[string]string `json:"labels,omitzero"`
+	InsertedAt    string            `json:"inserted_at,omitempty"`
 }
 
 // Device represents a TowerOps device.
@@ -62,13 +127,14 @@ type Device struct {
 	SNMPPort             *int    `json:"snmp_port,omitempty"`
 	CheckIntervalSeconds *int    `json:"check_interval_seconds,omitempty"`
 	// SNMPv3 fields
-	SNMPv3SecurityLevel *string `json:"snmpv3_security_level,omitempty"`
-	SNMPv3Username      *string `json:"snmpv3_username,omitempty"`
-	SNMPv3AuthProtocol  *string `json:"snmpv3_auth_protocol,omitempty"`
-	SNMPv3AuthPassword  *string `json:"snmpv3_auth_password,omitempty"`
-	SNMPv3PrivProtocol  *string `json:"snmpv3_priv_protocol,omitempty"`
-	SNMPv3PrivPassword  *string `json:"snmpv3_priv_password,omitempty"`
-	InsertedAt          string  `json:"inserted_at,omitempty"`
+	SNMPv3SecurityLevel *string           `json:"snmpv3_security_level,omitempty"`
+	SNMPv3Username      *string           `json:"snmpv3_username,omitempty"`
+	SNMPv3AuthProtocol  *string           `json:"snmpv3_auth_protocol,omitempty"`
+	SNMPv3AuthPassword  *string           `json:"snmpv3_auth_password,omitempty"`
+	SNMPv3PrivProtocol  *string           `json:"snmpv3_priv_protocol,omitempty"`
+	SNMPv3PrivPassword  *string           `json:"snmpv3_priv_password,omitempty"`
+	Labels              map[string]string `json:"labels,omitzero"`
+	InsertedAt          string            `json:"inserted_at,omitempty"`
 }
 
 // Organization represents a TowerOps organization.
@@ -92,6 +158,10 @@ type APIError struct {
 }
 
 func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error) {
+	if c.ReadOnly && method != http.MethodGet {
+		return nil, fmt.Errorf("%w: refusing to send %s %s. Set read_only = false (or unset TOWEROPS_READ_ONLY) to allow changes", ErrReadOnly, method, path)
+	}
+
 	var reqBody io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
@@ -125,21 +195,75 @@ func (c *Client) doRequest(method, path string, body interface{}) ([]byte, error
 		if resp.StatusCode == http.StatusNotFound {
 			return nil, ErrNotFound
 		}
-		var apiErr APIError
-		if err := json.Unmarshal(respBody, &apiErr); err == nil {
-			if apiErr.Error != "" {
-				return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, apiErr.Error)
-			}
-			if len(apiErr.Errors) > 0 {
-				return nil, fmt.Errorf("API validation error (%d): %v", resp.StatusCode, apiErr.Errors)
-			}
+		err := parseAPIError(resp.StatusCode, respBody)
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+		case http.StatusForbidden:
+			return nil, fmt.Errorf("%w: %v", ErrForbidden, err)
+		case http.StatusConflict:
+			return nil, fmt.Errorf("%w: %v", ErrConflict, err)
 		}
-		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(respBody))
+		return nil, err
 	}
 
 	return respBody, nil
 }
 
+// parseAPIError builds an error from a non-2xx response body.
+func parseAPIError(statusCode int, respBody []byte) error {
+	var apiErr APIError
+	if err := json.Unmarshal(respBody, &apiErr); err == nil {
+		if apiErr.Error != "" {
+			return fmt.Errorf("API error (%d): %s", statusCode, apiErr.Error)
+		}
+		if len(apiErr.Errors) > 0 {
+			return fmt.Errorf("API validation error (%d): %v", statusCode, apiErr.Errors)
+		}
+	}
+	return fmt.Errorf("API error (%d): %s", statusCode, string(respBody))
+}
+
+// listResponse is the envelope returned by the paginated list endpoints.
+type listResponse[T any] struct {
+	Data []T `json:"data"`
+	Meta struct {
+		NextCursor string `json:"next_cursor"`
+	} `json:"meta"`
+}
+
+// listAll fetches every page of a list endpoint.
+func listAll[T any](c *Client, path string, query url.Values) ([]T, error) {
+	query = maps.Clone(query)
+	if query == nil {
+		query = url.Values{}
+	}
+
+	var all []T
+	for {
+		reqPath := path
+		if encoded := query.Encode(); encoded != "" {
+			reqPath += "?" + encoded
+		}
+
+		respBody, err := c.doRequest(http.MethodGet, reqPath, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page listResponse[T]
+		if err := json.Unmarshal(respBody, &page); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+
+		all = append(all, page.Data...)
+		if page.Meta.NextCursor == "" {
+			return all, nil
+		}
+		query.Set("cursor", page.Meta.NextCursor)
+	}
+}
+
 // CreateSite creates a new site.
 func (c *Client) CreateSite(site Site) (*Site, error) {
 	body := map[string]Site{"site": site}
@@ -171,6 +295,11 @@ func (c *Client) GetSite(id string) (*Site, error) {
 	return &result, nil
 }
 
+// ListSites returns all sites.
+func (c *Client) ListSites() ([]Site, error) {
+	return listAll[Site](c, "/api/v1/sites", nil)
+}
+
 // UpdateSite updates an existing site.
 func (c *Client) UpdateSite(id string, site Site) (*Site, error) {
 	body := map[string]Site{"site": site}
@@ -224,6 +353,16 @@ func (c *Client) GetDevice(id string) (*Device, error) {
 	return &result, nil
 }
 
+// ListDevices returns all devices, optionally limited to a single site.
+func (c *Client) ListDevices(siteID string) ([]Device, error) {
+	query := url.Values{}
+	if siteID != "" {
+		query.Set("site_id", siteID)
+	}
+
+	return listAll[Device](c, "/api/v1/devices", query)
+}
+
 // UpdateDevice updates an existing device.
 func (c *Client) UpdateDevice(id string, device Device) (*Device, error) {
 	body := map[string]Device{"device": device}
@@ -240,19 +379,87 @@ func (c *Client) UpdateDevice(id string, device Device) (*Device, error) {
 	return &result, nil
 }
 
+// MoveDevice moves a device to another site, or out of its site into the
+// organization when siteID is nil. The device keeps its ID and history.
+func (c *Client) MoveDevice(id string, siteID *string) (*Device, error) {
+	// site_id is always sent, as null when moving out of a site, which the
+	// omitempty tag on Device would drop.
+	body := map[string]map[string]*string{"device": {"site_id": siteID}}
+	respBody, err := c.doRequest(http.MethodPatch, "/api/v1/devices/"+id, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result Device
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
 // DeleteDevice deletes a device.
 func (c *Client) DeleteDevice(id string) error {
 	_, err := c.doRequest(http.MethodDelete, "/api/v1/devices/"+id, nil)
 	return err
 }
 
+// DeviceDiscovery represents an SNMP discovery run for a device.
+type DeviceDiscovery struct {
+	ID          string  `json:"id"`
+	DeviceID    string  `json:"device_id"`
+	Status      string  `json:"status"`
+	Message     *string `json:"message,omitempty"`
+	StartedAt   *string `json:"started_at,omitempty"`
+	CompletedAt *string `json:"completed_at,omitempty"`
+}
+
+// Discovery statuses reported by the API.
+const (
+	DiscoveryStatusPending   = "pending"
+	DiscoveryStatusRunning   = "running"
+	DiscoveryStatusCompleted = "completed"
+	DiscoveryStatusFailed    = "failed"
+)
+
+// RediscoverDevice starts an SNMP rediscovery for a device.
+func (c *Client) RediscoverDevice(deviceID string) (*DeviceDiscovery, error) {
+	respBody, err := c.doRequest(http.MethodPost, "/api/v1/devices/"+deviceID+"/rediscover", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result DeviceDiscovery
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetDeviceDiscovery retrieves the status of a device discovery run.
+func (c *Client) GetDeviceDiscovery(deviceID, discoveryID string) (*DeviceDiscovery, error) {
+	respBody, err := c.doRequest(http.MethodGet, "/api/v1/devices/"+deviceID+"/discoveries/"+discoveryID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result DeviceDiscovery
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
 // OnCallSchedule represents a TowerOps on-call schedule.
 type OnCallSchedule struct {
-	ID          string  `json:"id,omitempty"`
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
-	Timezone    string  `json:"timezone"`
-	InsertedAt  string  `json:"inserted_at,omitempty"`
+	ID          string            `json:"id,omitempty"`
+	Name        string            `json:"name"`
+	Description *string           `json:"description,omitempty"`
+	Timezone    string            `json:"timezone"`
+	Labels      map[string]string `json:"labels,omitzero"`
+	InsertedAt  string            `json:"inserted_at,omitempty"`
 }
 
 // CreateSchedule creates a new on-call schedule.
@@ -286,6 +493,11 @@ func (c *Client) GetSchedule(id string) (*OnCallSchedule, error) {
 	return &result, nil
 }
 
+// ListSchedules returns all on-call schedules.
+func (c *Client) ListSchedules() ([]OnCallSchedule, error) {
+	return listAll[OnCallSchedule](c, "/api/v1/schedules", nil)
+}
+
 // UpdateSchedule updates an existing on-call schedule.
 func (c *Client) UpdateSchedule(id string, schedule OnCallSchedule) (*OnCallSchedule, error) {
 	body := map[string]OnCallSchedule{"schedule": schedule}
@@ -310,11 +522,12 @@ func (c *Client) DeleteSchedule(id string) error {
 
 // EscalationPolicyAPI represents a TowerOps escalation policy.
 type EscalationPolicyAPI struct {
-	ID          string  `json:"id,omitempty"`
-	Name        string  `json:"name"`
-	Description *string `json:"description,omitempty"`
-	RepeatCount *int    `json:"repeat_count,omitempty"`
-	InsertedAt  string  `json:"inserted_at,omitempty"`
+	ID          string            `json:"id,omitempty"`
+	Name        string            `json:"name"`
+	Description *string           `json:"description,omitempty"`
+	RepeatCount *int              `json:"repeat_count,omitempty"`
+	Labels      map[string]string `json:"labels,omitzero"`
+	InsertedAt  string            `json:"inserted_at,omitempty"`
 }
 
 // CreateEscalationPolicy creates a new escalation policy.
@@ -348,6 +561,11 @@ func (c *Client) GetEscalationPolicy(id string) (*EscalationPolicyAPI, error) {
 	return &result, nil
 }
 
+// ListEscalationPolicies returns all escalation policies.
+func (c *Client) ListEscalationPolicies() ([]EscalationPolicyAPI, error) {
+	return listAll[EscalationPolicyAPI](c, "/api/v1/escalation_policies", nil)
+}
+
 // UpdateEscalationPolicy updates an existing escalation policy.
 func (c *Client) UpdateEscalationPolicy(id string, policy EscalationPolicyAPI) (*EscalationPolicyAPI, error) {
 	body := map[string]EscalationPolicyAPI{"escalation_policy": policy}
@@ -372,12 +590,13 @@ func (c *Client) DeleteEscalationPolicy(id string) error {
 
 // Agent represents a TowerOps agent token.
 type Agent struct {
-	ID         string  `json:"id,omitempty"`
-	Name       string  `json:"name"`
-	Enabled    *bool   `json:"enabled,omitempty"`
-	LastSeenAt *string `json:"last_seen_at,omitempty"`
-	InsertedAt string  `json:"inserted_at,omitempty"`
-	Token      string  `json:"token,omitempty"`
+	ID         string            `json:"id,omitempty"`
+	Name       string            `json:"name"`
+	Enabled    *bool             `json:"enabled,omitempty"`
+	LastSeenAt *string           `json:"last_seen_at,omitempty"`
+	Labels     map[string]string `json:"labels,omitzero"`
+	InsertedAt string            `json:"inserted_at,omitempty"`
+	Token      string            `json:"token,omitempty"`
 }
 
 // agentCreateResponse wraps the create response which includes token.
@@ -416,6 +635,39 @@ func (c *Client) GetAgent(id string) (*Agent, error) {
 	return &result, nil
 }
 
+// ListAgents returns all agents.
+func (c *Client) ListAgents() ([]Agent, error) {
+	return listAll[Agent](c, "/api/v1/agents", nil)
+}
+
+// AgentToken represents a freshly issued agent bearer token.
+type AgentToken struct {
+	ID        string  `json:"id,omitempty"`
+	Token     string  `json:"token"`
+	ExpiresAt *string `json:"expires_at,omitempty"`
+}
+
+// CreateAgentToken issues a new bearer token for an existing agent.
+func (c *Client) CreateAgentToken(agentID string) (*AgentToken, error) {
+	respBody, err := c.doRequest(http.MethodPost, "/api/v1/agents/"+agentID+"/token", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result AgentToken
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// RevokeAgentToken revokes a token issued by CreateAgentToken.
+func (c *Client) RevokeAgentToken(agentID, tokenID string) error {
+	_, err := c.doRequest(http.MethodDelete, "/api/v1/agents/"+agentID+"/token/"+tokenID, nil)
+	return err
+}
+
 // DeleteAgent deletes an agent.
 func (c *Client) DeleteAgent(id string) error {
 	_, err := c.doRequest(http.MethodDelete, "/api/v1/agents/"+id, nil)
@@ -424,12 +676,13 @@ func (c *Client) DeleteAgent(id string) error {
 
 // Integration represents a TowerOps integration.
 type Integration struct {
-	ID                  string  `json:"id,omitempty"`
-	Provider            string  `json:"provider"`
-	Enabled             *bool   `json:"enabled,omitempty"`
-	SyncIntervalMinutes *int    `json:"sync_interval_minutes,omitempty"`
-	InsertedAt          string  `json:"inserted_at,omitempty"`
-	UpdatedAt           string  `json:"updated_at,omitempty"`
+	ID                  string            `json:"id,omitempty"`
+	Provider            string            `json:"provider"`
+	Enabled             *bool             `json:"enabled,omitempty"`
+	SyncIntervalMinutes *int              `json:"sync_interval_minutes,omitempty"`
+	Labels              map[string]string `json:"labels,omitzero"`
+	InsertedAt          string            `json:"inserted_at,omitempty"`
+	UpdatedAt           string            `json:"updated_at,omitempty"`
 }
 
 // integrationWithCredentials is used for create/update requests that include credentials.
@@ -438,6 +691,7 @@ type integrationWithCredentials struct {
 	Enabled             *bool                  `json:"enabled,omitempty"`
 	Credentials         map[string]interface{} `json:"credentials,omitempty"`
 	SyncIntervalMinutes *int                   `json:"sync_interval_minutes,omitempty"`
+	Labels              map[string]string      `json:"labels,omitzero"`
 }
 
 // CreateIntegration creates a new integration.
@@ -471,6 +725,11 @@ func (c *Client) GetIntegration(id string) (*Integration, error) {
 	return &result, nil
 }
 
+// ListIntegrations returns all integrations.
+func (c *Client) ListIntegrations() ([]Integration, error) {
+	return listAll[Integration](c, "/api/v1/integrations", nil)
+}
+
 // UpdateIntegration updates an existing integration.
 func (c *Client) UpdateIntegration(id string, integration integrationWithCredentials) (*Integration, error) {
 	body := map[string]integrationWithCredentials{"integration": integration}
@@ -493,17 +752,67 @@ func (c *Client) DeleteIntegration(id string) error {
 	return err
 }
 
+// IntegrationSync represents a sync run for an integration.
+type IntegrationSync struct {
+	ID             string  `json:"id"`
+	IntegrationID  string  `json:"integration_id"`
+	Status         string  `json:"status"`
+	RecordsCreated int64   `json:"records_created"`
+	RecordsUpdated int64   `json:"records_updated"`
+	RecordsFailed  int64   `json:"records_failed"`
+	Message        *string `json:"message,omitempty"`
+}
+
+// Integration sync statuses reported by the API.
+const (
+	SyncStatusPending   = "pending"
+	SyncStatusRunning   = "running"
+	SyncStatusCompleted = "completed"
+	SyncStatusFailed    = "failed"
+)
+
+// SyncIntegration starts an immediate sync for an integration.
+func (c *Client) SyncIntegration(integrationID string) (*IntegrationSync, error) {
+	respBody, err := c.doRequest(http.MethodPost, "/api/v1/integrations/"+integrationID+"/sync", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result IntegrationSync
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetIntegrationSync retrieves the status of an integration sync run.
+func (c *Client) GetIntegrationSync(integrationID, syncID string) (*IntegrationSync, error) {
+	respBody, err := c.doRequest(http.MethodGet, "/api/v1/integrations/"+integrationID+"/syncs/"+syncID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result IntegrationSync
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
 // MaintenanceWindowAPI represents a TowerOps maintenance window.
 type MaintenanceWindowAPI struct {
-	ID             string  `json:"id,omitempty"`
-	Name           string  `json:"name"`
-	Reason         *string `json:"reason,omitempty"`
-	StartsAt       string  `json:"starts_at"`
-	EndsAt         string  `json:"ends_at"`
-	SuppressAlerts *bool   `json:"suppress_alerts,omitempty"`
-	SiteID         *string `json:"site_id,omitempty"`
-	DeviceID       *string `json:"device_id,omitempty"`
-	InsertedAt     string  `json:"inserted_at,omitempty"`
+	ID             string            `json:"id,omitempty"`
+	Name           string            `json:"name"`
+	Reason         *string           `json:"reason,omitempty"`
+	StartsAt       string            `json:"starts_at"`
+	EndsAt         string            `json:"ends_at"`
+	SuppressAlerts *bool             `json:"suppress_alerts,omitempty"`
+	SiteID         *string           `json:"site_id,omitempty"`
+	DeviceID       *string           `json:"device_id,omitempty"`
+	Labels         map[string]string `json:"labels,omitzero"`
+	InsertedAt     string            `json:"inserted_at,omitempty"`
 }
 
 // CreateMaintenanceWindow creates a new maintenance window.
@@ -537,6 +846,11 @@ func (c *Client) GetMaintenanceWindow(id string) (*MaintenanceWindowAPI, error)
 	return &result, nil
 }
 
+// ListMaintenanceWindows returns all maintenance windows.
+func (c *Client) ListMaintenanceWindows() ([]MaintenanceWindowAPI, error) {
+	return listAll[MaintenanceWindowAPI](c, "/api/v1/maintenance_windows", nil)
+}
+
 // UpdateMaintenanceWindow updates an existing maintenance window.
 func (c *Client) UpdateMaintenanceWindow(id string, window MaintenanceWindowAPI) (*MaintenanceWindowAPI, error) {
 	body := map[string]MaintenanceWindowAPI{"maintenance_window": window}
@@ -559,6 +873,114 @@ func (c *Client) DeleteMaintenanceWindow(id string) error {
 	return err
 }
 
+// APITokenRequest describes a scoped, short-lived API token to issue.
+type APITokenRequest struct {
+	Scopes      []string `json:"scopes"`
+	TTLSeconds  *int     `json:"ttl_seconds,omitempty"`
+	Description *string  `json:"description,omitempty"`
+}
+
+// APIToken represents a scoped, short-lived API token.
+type APIToken struct {
+	ID        string   `json:"id,omitempty"`
+	Token     string   `json:"token"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at,omitempty"`
+}
+
+// CreateAPIToken exchanges the client's token for a scoped, short-lived token.
+func (c *Client) CreateAPIToken(token APITokenRequest) (*APIToken, error) {
+	body := map[string]APITokenRequest{"api_token": token}
+	respBody, err := c.doRequest(http.MethodPost, "/api/v1/api_tokens", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result APIToken
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// RevokeAPIToken revokes a token issued by CreateAPIToken.
+func (c *Client) RevokeAPIToken(id string) error {
+	_, err := c.doRequest(http.MethodDelete, "/api/v1/api_tokens/"+id, nil)
+	return err
+}
+
+// OrganizationID returns the ID of the organization the token belongs to.
+// The ID is looked up on first use and cached for the life of the client.
+func (c *Client) OrganizationID() (string, error) {
+	org, err := c.CurrentOrganization()
+	if err != nil {
+		return "", err
+	}
+
+	return org.ID, nil
+}
+
+// CurrentOrganization returns the settings of the organization the token
+// belongs to. They are looked up on first use and cached for the life of the
+// client, which is a single Terraform run. UpdateOrganization refreshes the
+// cache.
+func (c *Client) CurrentOrganization() (*Organization, error) {
+	c.orgMu.Lock()
+	defer c.orgMu.Unlock()
+
+	if c.org != nil {
+		return c.org, nil
+	}
+
+	org, err := c.GetOrganization()
+	if err != nil {
+		return nil, err
+	}
+
+	c.org = org
+	return c.org, nil
+}
+
+// SetPlannedUseSites records the use_sites value planned for the
+// organization, so resources planned later in the same run can be checked
+// against it.
+func (c *Client) SetPlannedUseSites(plan UseSitesPlan) {
+	c.orgMu.Lock()
+	defer c.orgMu.Unlock()
+
+	c.plannedUseSites = &plan
+	c.organizationManaged = true
+}
+
+// PlannedUseSites returns the use_sites value recorded by SetPlannedUseSites,
+// or nil when none was recorded.
+func (c *Client) PlannedUseSites() *UseSitesPlan {
+	c.orgMu.Lock()
+	defer c.orgMu.Unlock()
+
+	return c.plannedUseSites
+}
+
+// SetOrganizationManaged records that the configuration manages the
+// organization with towerops_organization.
+func (c *Client) SetOrganizationManaged() {
+	c.orgMu.Lock()
+	defer c.orgMu.Unlock()
+
+	c.organizationManaged = true
+}
+
+// OrganizationManaged reports whether a towerops_organization has been seen
+// in this run. Resources planned before it cannot tell it apart from a
+// configuration without one.
+func (c *Client) OrganizationManaged() bool {
+	c.orgMu.Lock()
+	defer c.orgMu.Unlock()
+
+	return c.organizationManaged
+}
+
 // GetOrganization retrieves the current organization settings.
 func (c *Client) GetOrganization() (*Organization, error) {
 	respBody, err := c.doRequest(http.MethodGet, "/api/v1/organization", nil)
@@ -587,5 +1009,9 @@ func (c *Client) UpdateOrganization(org Organization) (*Organization, error) {
 		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
 	}
 
+	c.orgMu.Lock()
+	c.org = &result.Data
+	c.orgMu.Unlock()
+
 	return &result.Data, nil
 }
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -289,8 +290,8 @@ func TestClient_GetSite_NotFound(t *testing.T) {
 
 func TestNewClient_DefaultBaseURL(t *testing.T) {
 	client := NewClient("test-token", "")
-	if client.BaseURL != defaultBaseURL {
-		t.Errorf("expected default base URL %s, got %s", defaultBaseURL, client.BaseURL)
+	if client.BaseURL != DefaultBaseURL {
+		t.Errorf("expected default base URL %s, got %s", DefaultBaseURL, client.BaseURL)
 	}
 }
 
@@ -573,3 +574,332 @@ func TestClient_APIErrorWithoutJSON(t *testing.T) {
 		t.Error("did not expect ErrNotFound for 500 error")
 	}
 }
+
+func TestClient_ReadOnly_RejectsMutations(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s request to %s in read-only mode", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+	client.ReadOnly = true
+
+	_, err := client.CreateSite(Site{Name: "Test"})
+	if !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly from create, got: %v", err)
+	}
+
+	_, err = client.UpdateDevice("device-123", Device{IPAddress: "192.168.1.1"})
+	if !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly from update, got: %v", err)
+	}
+
+	err = client.DeleteSite("site-123")
+	if !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly from delete, got: %v", err)
+	}
+}
+
+func TestClient_ReadOnly_AllowsReads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "site-123", "name": "Test Site"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+	client.ReadOnly = true
+
+	site, err := client.GetSite("site-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if site.Name != "Test Site" {
+		t.Errorf("expected Name 'Test Site', got %s", site.Name)
+	}
+}
+
+func TestClient_Unauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "invalid token"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("bad-token", server.URL)
+
+	_, err := client.GetOrganization()
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("expected ErrUnauthorized, got: %v", err)
+	}
+}
+
+func TestClient_Forbidden(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": "insufficient role"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+
+	_, err := client.GetOrganization()
+	if !errors.Is(err, ErrForbidden) {
+		t.Errorf("expected ErrForbidden, got: %v", err)
+	}
+}
+
+func TestClient_CreateAgentToken_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/agents/agent-123/token" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{
+			"token": "agt_secret",
+			"expires_at": "2024-01-02T00:00:00Z"
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+
+	token, err := client.CreateAgentToken("agent-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if token.Token != "agt_secret" {
+		t.Errorf("expected token agt_secret, got %s", token.Token)
+	}
+	if token.ExpiresAt == nil || *token.ExpiresAt != "2024-01-02T00:00:00Z" {
+		t.Errorf("expected ExpiresAt 2024-01-02T00:00:00Z, got %v", token.ExpiresAt)
+	}
+}
+
+func TestClient_CreateAPIToken_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/api_tokens" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var body map[string]APITokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		req := body["api_token"]
+		if len(req.Scopes) != 1 || req.Scopes[0] != "devices:read" {
+			t.Errorf("unexpected scopes: %v", req.Scopes)
+		}
+		if req.TTLSeconds == nil || *req.TTLSeconds != 900 {
+			t.Errorf("expected ttl_seconds 900, got %v", req.TTLSeconds)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{
+			"id": "token-123",
+			"token": "tok_scoped",
+			"scopes": ["devices:read"],
+			"expires_at": "2024-01-01T00:15:00Z"
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+
+	ttl := 900
+	token, err := client.CreateAPIToken(APITokenRequest{
+		Scopes:     []string{"devices:read"},
+		TTLSeconds: &ttl,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if token.Token != "tok_scoped" {
+		t.Errorf("expected token tok_scoped, got %s", token.Token)
+	}
+	if token.ExpiresAt != "2024-01-01T00:15:00Z" {
+		t.Errorf("expected ExpiresAt 2024-01-01T00:15:00Z, got %s", token.ExpiresAt)
+	}
+}
+
+func TestClient_MoveDevice_OutOfSite(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			t.Errorf("expected PATCH, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/devices/device-123" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var body map[string]map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if siteID, ok := body["device"]["site_id"]; !ok || siteID != nil {
+			t.Errorf("expected site_id to be sent as null, got %v", body)
+		}
+
+		w.Write([]byte(`{"id": "device-123", "organization_id": "org-1", "ip_address": "10.0.0.1"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+	device, err := client.MoveDevice("device-123", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if device.SiteID != nil {
+		t.Errorf("expected no site, got %s", *device.SiteID)
+	}
+}
+
+func TestClient_RediscoverDevice_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("expected POST, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/devices/device-123/rediscover" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"id": "disc-1", "device_id": "device-123", "status": "pending"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+	discovery, err := client.RediscoverDevice("device-123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if discovery.ID != "disc-1" {
+		t.Errorf("expected ID disc-1, got %s", discovery.ID)
+	}
+	if discovery.Status != DiscoveryStatusPending {
+		t.Errorf("expected status pending, got %s", discovery.Status)
+	}
+}
+
+func TestClient_GetIntegrationSync_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("expected GET, got %s", r.Method)
+		}
+		if r.URL.Path != "/api/v1/integrations/int-123/syncs/sync-1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		w.Write([]byte(`{
+			"id": "sync-1",
+			"integration_id": "int-123",
+			"status": "completed",
+			"records_created": 5,
+			"records_updated": 2,
+			"records_failed": 1
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+	sync, err := client.GetIntegrationSync("int-123", "sync-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if sync.Status != SyncStatusCompleted {
+		t.Errorf("expected status completed, got %s", sync.Status)
+	}
+	if sync.RecordsCreated != 5 || sync.RecordsUpdated != 2 || sync.RecordsFailed != 1 {
+		t.Errorf("unexpected record counts: %+v", sync)
+	}
+}
+
+func TestClient_ListSites_Pagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/sites" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			w.Write([]byte(`{"data": [{"id": "site-1", "name": "Tower A"}], "meta": {"next_cursor": "page-2"}}`))
+		case "page-2":
+			w.Write([]byte(`{"data": [{"id": "site-2", "name": "Tower B"}], "meta": {}}`))
+		default:
+			t.Errorf("unexpected cursor: %s", r.URL.Query().Get("cursor"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+	sites, err := client.ListSites()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(sites) != 2 {
+		t.Fatalf("expected 2 sites, got %d", len(sites))
+	}
+	if sites[0].ID != "site-1" || sites[1].ID != "site-2" {
+		t.Errorf("unexpected sites: %+v", sites)
+	}
+}
+
+func TestClient_CurrentOrganization_Cached(t *testing.T) {
+	gets := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			gets++
+			w.Write([]byte(`{"data": {"id": "org-1", "use_sites": false}}`))
+		case http.MethodPatch:
+			w.Write([]byte(`{"data": {"id": "org-1", "use_sites": true}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+
+	for range 2 {
+		org, err := client.CurrentOrganization()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if org.UseSites {
+			t.Errorf("expected use_sites false, got true")
+		}
+	}
+	if gets != 1 {
+		t.Errorf("expected 1 GET, got %d", gets)
+	}
+
+	if _, err := client.UpdateOrganization(Organization{UseSites: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	org, err := client.CurrentOrganization()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !org.UseSites {
+		t.Errorf("expected the update to refresh the cached organization")
+	}
+	if gets != 1 {
+		t.Errorf("expected 1 GET, got %d", gets)
+	}
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// deletionProtectionAttribute returns the schema for deletion_protection.
+// The value only lives in Terraform state and is never sent to the API.
+func deletionProtectionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		Description: "When true, Terraform refuses to delete this resource. Set it to false and apply before destroying or replacing the resource. Defaults to the provider's deletion_protection, or false.",
+		Optional:    true,
+		Computed:    true,
+	}
+}
+
+// defaultDeletionProtection returns the provider-level deletion_protection.
+func defaultDeletionProtection(client *Client) bool {
+	return client != nil && client.DeletionProtection
+}
+
+// modifyPlanDeletionProtection sets deletion_protection in the plan to the
+// provider default when the configuration leaves it unset.
+func modifyPlanDeletionProtection(ctx context.Context, client *Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configured types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("deletion_protection"), &configured)...)
+	if resp.Diagnostics.HasError() || !configured.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("deletion_protection"), types.BoolValue(defaultDeletionProtection(client)))...)
+}
+
+// checkDeletionProtection returns an error when a resource about to be
+// deleted has deletion_protection enabled in state.
+func checkDeletionProtection(protected types.Bool, kind, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if protected.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Deletion Protection Enabled",
+			fmt.Sprintf("The %s %s has deletion_protection enabled and was not deleted. Set deletion_protection = false and apply that change before destroying it.", kind, id),
+		)
+	}
+
+	return diags
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestDeviceResource_Delete_deletionProtection(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodDelete {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	r := &DeviceResource{client: NewClient("test-token", server.URL)}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &DeviceResourceModel{
+		ID:                 types.StringValue("device-123"),
+		IPAddress:          NewIPAddressValue("10.0.0.1"),
+		Labels:             types.MapNull(types.StringType),
+		LabelsAll:          types.MapNull(types.StringType),
+		DeletionProtection: types.BoolValue(true),
+	}); diags.HasError() {
+		t.Fatalf("unexpected error building state: %v", diags)
+	}
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected deletion protection error")
+	}
+	if summary := resp.Diagnostics.Errors()[0].Summary(); summary != "Deletion Protection Enabled" {
+		t.Errorf("expected Deletion Protection Enabled, got %q", summary)
+	}
+}
+
+func TestCheckDeletionProtection(t *testing.T) {
+	for _, protected := range []types.Bool{types.BoolNull(), types.BoolValue(false)} {
+		if diags := checkDeletionProtection(protected, "site", "site-123"); diags.HasError() {
+			t.Errorf("expected no error for %s, got: %v", protected, diags)
+		}
+	}
+}
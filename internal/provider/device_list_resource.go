@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &DeviceListResource{}
+var _ list.ListResourceWithConfigure = &DeviceListResource{}
+var _ list.ListResourceWithValidateConfig = &DeviceListResource{}
+
+// DeviceListResource lists existing devices for terraform query.
+type DeviceListResource struct {
+	client *Client
+}
+
+// DeviceListResourceModel describes the list filters.
+type DeviceListResourceModel struct {
+	SiteID     types.String `tfsdk:"site_id"`
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	IPRange    types.String `tfsdk:"ip_range"`
+}
+
+// NewDeviceListResource creates a new device list resource.
+func NewDeviceListResource() list.ListResource {
+	return &DeviceListResource{}
+}
+
+func (r *DeviceListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device"
+}
+
+func (r *DeviceListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing TowerOps devices.",
+		Attributes: map[string]schema.Attribute{
+			"site_id": schema.StringAttribute{
+				Description: "Only list devices that belong to this site.",
+				Optional:    true,
+			},
+			"name_prefix": schema.StringAttribute{
+				Description: "Only list devices whose name starts with this prefix.",
+				Optional:    true,
+			},
+			"ip_range": schema.StringAttribute{
+				Description: "Only list devices whose IP address falls within this CIDR range (e.g. 10.20.0.0/16).",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *DeviceListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *DeviceListResource) ValidateListResourceConfig(ctx context.Context, req list.ValidateConfigRequest, resp *list.ValidateConfigResponse) {
+	var config DeviceListResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.IPRange.IsNull() || config.IPRange.IsUnknown() {
+		return
+	}
+
+	if _, err := netip.ParsePrefix(config.IPRange.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ip_range"),
+			"Invalid IP Range",
+			fmt.Sprintf("ip_range must be a CIDR range such as 10.20.0.0/16: %s", err),
+		)
+	}
+}
+
+func (r *DeviceListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config DeviceListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	var ipRange netip.Prefix
+	if !config.IPRange.IsNull() {
+		prefix, err := netip.ParsePrefix(config.IPRange.ValueString())
+		if err != nil {
+			stream.Results = listErrorStream("Invalid IP Range", err.Error())
+			return
+		}
+		ipRange = prefix.Masked()
+	}
+
+	devices, err := r.client.ListDevices(config.SiteID.ValueString())
+	if err != nil {
+		stream.Results = listErrorStream("Failed to list devices", err.Error())
+		return
+	}
+
+	var matched []Device
+	for _, device := range devices {
+		name := ""
+		if device.Name != nil {
+			name = *device.Name
+		}
+		if !strings.HasPrefix(name, config.NamePrefix.ValueString()) {
+			continue
+		}
+
+		if ipRange.IsValid() {
+			addr, err := netip.ParseAddr(device.IPAddress)
+			if err != nil || !ipRange.Contains(addr) {
+				continue
+			}
+		}
+
+		matched = append(matched, device)
+	}
+
+	stream.Results = streamListResults(ctx, r.client, req, matched,
+		func(device Device) string { return device.ID },
+		func(device Device, result *list.ListResult) {
+			result.DisplayName = device.IPAddress
+			if device.Name != nil {
+				result.DisplayName = fmt.Sprintf("%s (%s)", *device.Name, device.IPAddress)
+			}
+			if !req.IncludeResource {
+				return
+			}
+
+			data := DeviceResourceModel{
+				ID: types.StringValue(device.ID),
+			}
+			setDeviceFieldsFromAPI(&data, &device)
+
+			var d diag.Diagnostics
+			data.Labels, data.LabelsAll, d = labelsForImport(ctx, r.client, device.Labels)
+			result.Diagnostics.Append(d...)
+
+			result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+		},
+	)
+}
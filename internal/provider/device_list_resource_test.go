@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newDeviceListRequest(t *testing.T, config DeviceListResourceModel, includeResource bool, limit int64) list.ListRequest {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &list.ListResourceSchemaResponse{}
+	NewDeviceListResource().ListResourceConfigSchema(ctx, list.ListResourceSchemaRequest{}, schemaResp)
+
+	// tfsdk.Plan is used only to encode the model into a raw config value.
+	raw := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := raw.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("unexpected error building config: %v", diags)
+	}
+
+	resourceSchemaResp := &fwresource.SchemaResponse{}
+	NewDeviceResource().Schema(ctx, fwresource.SchemaRequest{}, resourceSchemaResp)
+
+	identitySchemaResp := &fwresource.IdentitySchemaResponse{}
+	NewDeviceResource().(fwresource.ResourceWithIdentity).IdentitySchema(ctx, fwresource.IdentitySchemaRequest{}, identitySchemaResp)
+
+	return list.ListRequest{
+		Config:                 tfsdk.Config{Schema: schemaResp.Schema, Raw: raw.Raw},
+		IncludeResource:        includeResource,
+		Limit:                  limit,
+		ResourceSchema:         resourceSchemaResp.Schema,
+		ResourceIdentitySchema: identitySchemaResp.IdentitySchema,
+	}
+}
+
+func newDeviceListServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/api/v1/organization" {
+			w.Write([]byte(`{"data": {"id": "org-123", "name": "Test ISP"}}`))
+			return
+		}
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/devices" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.URL.Query().Get("site_id"); got != "site-1" {
+			t.Errorf("expected site_id query site-1, got %q", got)
+		}
+
+		w.Write([]byte(`{"data": [
+			{"id": "dev-1", "site_id": "site-1", "name": "tower-a-ap1", "ip_address": "10.20.1.10", "labels": {"env": "prod"}},
+			{"id": "dev-2", "site_id": "site-1", "name": "tower-a-ap2", "ip_address": "10.30.1.10"},
+			{"id": "dev-3", "site_id": "site-1", "name": "core-router", "ip_address": "10.20.0.1"},
+			{"id": "dev-4", "site_id": "site-1", "name": "tower-a-ap3", "ip_address": "10.20.2.10"}
+		]}`))
+	}))
+}
+
+func collectDeviceListResults(t *testing.T, r *DeviceListResource, req list.ListRequest) []list.ListResult {
+	t.Helper()
+
+	stream := &list.ListResultsStream{}
+	r.List(context.Background(), req, stream)
+
+	var results []list.ListResult
+	for result := range stream.Results {
+		if result.Diagnostics.HasError() {
+			t.Fatalf("unexpected error: %v", result.Diagnostics)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func TestDeviceListResource_List_filters(t *testing.T) {
+	server := newDeviceListServer(t)
+	defer server.Close()
+
+	r := &DeviceListResource{client: NewClient("test-token", server.URL)}
+	req := newDeviceListRequest(t, DeviceListResourceModel{
+		SiteID:     types.StringValue("site-1"),
+		NamePrefix: types.StringValue("tower-a-"),
+		IPRange:    types.StringValue("10.20.0.0/16"),
+	}, true, 0)
+
+	results := collectDeviceListResults(t, r, req)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(results))
+	}
+
+	var identity resourceIdentityModel
+	if diags := results[0].Identity.Get(context.Background(), &identity); diags.HasError() {
+		t.Fatalf("unexpected error reading identity: %v", diags)
+	}
+	if identity.ID.ValueString() != "dev-1" {
+		t.Errorf("expected identity dev-1, got %s", identity.ID.ValueString())
+	}
+	if identity.OrganizationID.ValueString() != "org-123" {
+		t.Errorf("expected identity organization org-123, got %s", identity.OrganizationID.ValueString())
+	}
+	if results[0].DisplayName != "tower-a-ap1 (10.20.1.10)" {
+		t.Errorf("unexpected display name: %s", results[0].DisplayName)
+	}
+
+	var data DeviceResourceModel
+	if diags := results[0].Resource.Get(context.Background(), &data); diags.HasError() {
+		t.Fatalf("unexpected error reading resource: %v", diags)
+	}
+	if data.IPAddress.ValueString() != "10.20.1.10" {
+		t.Errorf("expected ip_address 10.20.1.10, got %s", data.IPAddress.ValueString())
+	}
+	if v, ok := data.Labels.Elements()["env"]; !ok || v.(types.String).ValueString() != "prod" {
+		t.Errorf("expected labels to contain env=prod, got %v", data.Labels)
+	}
+}
+
+func TestDeviceListResource_List_limit(t *testing.T) {
+	server := newDeviceListServer(t)
+	defer server.Close()
+
+	r := &DeviceListResource{client: NewClient("test-token", server.URL)}
+	req := newDeviceListRequest(t, DeviceListResourceModel{
+		SiteID:     types.StringValue("site-1"),
+		NamePrefix: types.StringNull(),
+		IPRange:    types.StringNull(),
+	}, false, 3)
+
+	results := collectDeviceListResults(t, r, req)
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+}
@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultRediscoverTimeout = 5 * time.Minute
+
+var _ action.Action = &DeviceRediscoverAction{}
+var _ action.ActionWithConfigure = &DeviceRediscoverAction{}
+
+// DeviceRediscoverAction starts an SNMP rediscovery for a device.
+type DeviceRediscoverAction struct {
+	client       *Client
+	pollInterval time.Duration
+}
+
+// DeviceRediscoverActionModel describes the action data model.
+type DeviceRediscoverActionModel struct {
+	DeviceID       types.String `tfsdk:"device_id"`
+	Wait           types.Bool   `tfsdk:"wait"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+}
+
+// NewDeviceRediscoverAction creates a new device rediscover action.
+func NewDeviceRediscoverAction() action.Action {
+	return &DeviceRediscoverAction{
+		pollInterval: 5 * time.Second,
+	}
+}
+
+func (a *DeviceRediscoverAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_device_rediscover"
+}
+
+func (a *DeviceRediscoverAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Starts an SNMP rediscovery for a device, refreshing its auto-discovered name and hardware data without waiting for the next poll cycle. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"device_id": schema.StringAttribute{
+				Description: "The ID of the device to rediscover.",
+				Required:    true,
+			},
+			"wait": schema.BoolAttribute{
+				Description: "Whether to wait for the rediscovery to finish. Defaults to false.",
+				Optional:    true,
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Description: "How long to wait for the rediscovery to finish, in seconds. Only used when wait is true. Defaults to 300.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+		},
+	}
+}
+
+func (a *DeviceRediscoverAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
+
+func (a *DeviceRediscoverAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data DeviceRediscoverActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	deviceID := data.DeviceID.ValueString()
+
+	discovery, err := a.client.RediscoverDevice(deviceID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("device_id"),
+				"Device Not Found",
+				fmt.Sprintf("No device with ID %q exists.", deviceID),
+			)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to start device rediscovery", err.Error())
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Started rediscovery of device %s", deviceID),
+	})
+
+	if !data.Wait.ValueBool() {
+		return
+	}
+
+	timeout := defaultRediscoverTimeout
+	if !data.TimeoutSeconds.IsNull() {
+		timeout = time.Duration(data.TimeoutSeconds.ValueInt64()) * time.Second
+	}
+
+	err = pollUntil(ctx, a.pollInterval, timeout,
+		func() bool {
+			return discovery.Status == DiscoveryStatusCompleted || discovery.Status == DiscoveryStatusFailed
+		},
+		func() error {
+			next, err := a.client.GetDeviceDiscovery(deviceID, discovery.ID)
+			if err != nil {
+				return err
+			}
+			if next.Status != discovery.Status {
+				resp.SendProgress(action.InvokeProgressEvent{
+					Message: fmt.Sprintf("Rediscovery of device %s is %s", deviceID, next.Status),
+				})
+			}
+			discovery = next
+			return nil
+		},
+	)
+	switch {
+	case errors.Is(err, errPollTimeout):
+		resp.Diagnostics.AddError(
+			"Timed Out Waiting for Device Rediscovery",
+			fmt.Sprintf("Rediscovery of device %s was still %s after %s.", deviceID, discovery.Status, timeout),
+		)
+		return
+	case ctx.Err() != nil:
+		resp.Diagnostics.AddError("Device Rediscovery Cancelled", ctx.Err().Error())
+		return
+	case err != nil:
+		resp.Diagnostics.AddError("Failed to read device rediscovery status", err.Error())
+		return
+	}
+
+	if discovery.Status == DiscoveryStatusFailed {
+		detail := fmt.Sprintf("Rediscovery of device %s failed.", deviceID)
+		if discovery.Message != nil {
+			detail = fmt.Sprintf("Rediscovery of device %s failed: %s", deviceID, *discovery.Message)
+		}
+		resp.Diagnostics.AddError("Device Rediscovery Failed", detail)
+	}
+}
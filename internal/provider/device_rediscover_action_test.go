@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// newActionInvokeRequest builds an invoke request for a with config encoded
+// against its schema.
+func newActionInvokeRequest(t *testing.T, a action.Action, config any) action.InvokeRequest {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &action.SchemaResponse{}
+	a.Schema(ctx, action.SchemaRequest{}, schemaResp)
+
+	// tfsdk.Plan is used only to encode the model into a raw config value.
+	raw := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := raw.Set(ctx, config); diags.HasError() {
+		t.Fatalf("unexpected error building config: %v", diags)
+	}
+
+	return action.InvokeRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw.Raw},
+	}
+}
+
+func TestDeviceRediscoverAction_Invoke_wait(t *testing.T) {
+	var mu sync.Mutex
+	polls := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/devices/device-123/rediscover":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "disc-1", "device_id": "device-123", "status": "pending"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/devices/device-123/discoveries/disc-1":
+			polls++
+			status := "running"
+			if polls >= 2 {
+				status = "completed"
+			}
+			w.Write([]byte(`{"id": "disc-1", "device_id": "device-123", "status": "` + status + `"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	a := &DeviceRediscoverAction{
+		client:       NewClient("test-token", server.URL),
+		pollInterval: time.Millisecond,
+	}
+
+	req := newActionInvokeRequest(t, a, &DeviceRediscoverActionModel{
+		DeviceID:       types.StringValue("device-123"),
+		Wait:           types.BoolValue(true),
+		TimeoutSeconds: types.Int64Null(),
+	})
+
+	var messages []string
+	resp := &action.InvokeResponse{
+		SendProgress: func(event action.InvokeProgressEvent) {
+			messages = append(messages, event.Message)
+		},
+	}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	expected := []string{
+		"Started rediscovery of device device-123",
+		"Rediscovery of device device-123 is running",
+		"Rediscovery of device device-123 is completed",
+	}
+	if len(messages) != len(expected) {
+		t.Fatalf("expected progress messages %v, got %v", expected, messages)
+	}
+	for i := range expected {
+		if messages[i] != expected[i] {
+			t.Errorf("expected progress message %q, got %q", expected[i], messages[i])
+		}
+	}
+}
+
+func TestDeviceRediscoverAction_Invoke_failed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "disc-1", "device_id": "device-123", "status": "running"}`))
+		case http.MethodGet:
+			w.Write([]byte(`{"id": "disc-1", "device_id": "device-123", "status": "failed", "message": "SNMP timeout"}`))
+		}
+	}))
+	defer server.Close()
+
+	a := &DeviceRediscoverAction{
+		client:       NewClient("test-token", server.URL),
+		pollInterval: time.Millisecond,
+	}
+
+	req := newActionInvokeRequest(t, a, &DeviceRediscoverActionModel{
+		DeviceID:       types.StringValue("device-123"),
+		Wait:           types.BoolValue(true),
+		TimeoutSeconds: types.Int64Null(),
+	})
+	resp := &action.InvokeResponse{SendProgress: func(action.InvokeProgressEvent) {}}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a failed rediscovery")
+	}
+	if got := resp.Diagnostics.Errors()[0].Detail(); got != "Rediscovery of device device-123 failed: SNMP timeout" {
+		t.Errorf("unexpected error detail: %s", got)
+	}
+}
@@ -1,10 +1,15 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -13,11 +18,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ resource.Resource = &DeviceResource{}
+var _ resource.ResourceWithUpgradeState = &DeviceResource{}
 var _ resource.ResourceWithImportState = &DeviceResource{}
+var _ resource.ResourceWithIdentity = &DeviceResource{}
+var _ resource.ResourceWithModifyPlan = &DeviceResource{}
+var _ resource.ResourceWithValidateConfig = &DeviceResource{}
+var _ resource.ResourceWithConfigValidators = &DeviceResource{}
 
 // DeviceResource defines the resource implementation.
 type DeviceResource struct {
@@ -26,23 +38,33 @@ type DeviceResource struct {
 
 // DeviceResourceModel describes the resource data model.
 type DeviceResourceModel struct {
-	ID                   types.String `tfsdk:"id"`
-	SiteID               types.String `tfsdk:"site_id"`
-	OrganizationID       types.String `tfsdk:"organization_id"`
-	Name                 types.String `tfsdk:"name"`
-	IPAddress            types.String `tfsdk:"ip_address"`
-	Description          types.String `tfsdk:"description"`
-	MonitoringEnabled    types.Bool   `tfsdk:"monitoring_enabled"`
-	SNMPEnabled          types.Bool   `tfsdk:"snmp_enabled"`
-	SNMPVersion          types.String `tfsdk:"snmp_version"`
-	SNMPPort             types.Int64  `tfsdk:"snmp_port"`
-	SNMPv3SecurityLevel  types.String `tfsdk:"snmpv3_security_level"`
-	SNMPv3Username       types.String `tfsdk:"snmpv3_username"`
-	SNMPv3AuthProtocol   types.String `tfsdk:"snmpv3_auth_protocol"`
-	SNMPv3AuthPassword   types.String `tfsdk:"snmpv3_auth_password"`
-	SNMPv3PrivProtocol   types.String `tfsdk:"snmpv3_priv_protocol"`
-	SNMPv3PrivPassword   types.String `tfsdk:"snmpv3_priv_password"`
-	InsertedAt           types.String `tfsdk:"inserted_at"`
+	ID                  types.String   `tfsdk:"id"`
+	SiteID              types.String   `tfsdk:"site_id"`
+	OrganizationID      types.String   `tfsdk:"organization_id"`
+	Name                types.String   `tfsdk:"name"`
+	IPAddress           IPAddressValue `tfsdk:"ip_address"`
+	AllowHostname       types.Bool     `tfsdk:"allow_hostname"`
+	Description         types.String   `tfsdk:"description"`
+	MonitoringEnabled   types.Bool     `tfsdk:"monitoring_enabled"`
+	SNMPEnabled         types.Bool     `tfsdk:"snmp_enabled"`
+	SNMPVersion         types.String   `tfsdk:"snmp_version"`
+	SNMPPort            types.Int64    `tfsdk:"snmp_port"`
+	SNMPv3SecurityLevel types.String   `tfsdk:"snmpv3_security_level"`
+	SNMPv3Username      types.String   `tfsdk:"snmpv3_username"`
+	SNMPv3AuthProtocol  types.String   `tfsdk:"snmpv3_auth_protocol"`
+	SNMPv3AuthPassword  types.String   `tfsdk:"snmpv3_auth_password"`
+	SNMPv3PrivProtocol  types.String   `tfsdk:"snmpv3_priv_protocol"`
+	SNMPv3PrivPassword  types.String   `tfsdk:"snmpv3_priv_password"`
+
+	SNMPv3AuthPasswordWO        types.String `tfsdk:"snmpv3_auth_password_wo"`
+	SNMPv3AuthPasswordWOVersion types.Int64  `tfsdk:"snmpv3_auth_password_wo_version"`
+	SNMPv3PrivPasswordWO        types.String `tfsdk:"snmpv3_priv_password_wo"`
+	SNMPv3PrivPasswordWOVersion types.Int64  `tfsdk:"snmpv3_priv_password_wo_version"`
+
+	Labels             types.Map      `tfsdk:"labels"`
+	LabelsAll          types.Map      `tfsdk:"labels_all"`
+	InsertedAt         TimestampValue `tfsdk:"inserted_at"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
 }
 
 // NewDeviceResource creates a new device resource.
@@ -54,8 +76,13 @@ func (r *DeviceResource) Metadata(ctx context.Context, req resource.MetadataRequ
 	resp.TypeName = req.ProviderTypeName + "_device"
 }
 
+func (r *DeviceResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema()
+}
+
 func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages a TowerOps device. Devices represent network equipment at a site or directly in an organization.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -66,20 +93,21 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				},
 			},
 			"site_id": schema.StringAttribute{
-				Description: "The ID of the site this device belongs to. Optional if organization_id is provided.",
+				Description: "The ID of the site this device belongs to. Required when the organization uses sites (use_sites), and not allowed when it doesn't. Changing this moves the device in place, and removing it moves the device out of its site into the organization.",
 				Optional:    true,
 				Computed:    true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"organization_id": schema.StringAttribute{
-				Description: "The ID of the organization this device belongs to. Required if site_id is not provided.",
+				Description: "The ID of the organization this device belongs to. Required if site_id is not provided. Devices cannot move between organizations, so changing this from one organization to another forces a new resource to be created.",
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
-				stringplanmodifier.UseStateForUnknown(),
-				stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIf(
+						requiresReplaceIfOrganizationChanged,
+						"Devices cannot move between organizations, so changing organization_id from one organization to another forces a new resource to be created.",
+						"Devices cannot move between organizations, so changing `organization_id` from one organization to another forces a new resource to be created.",
+					),
 				},
 			},
 			"name": schema.StringAttribute{
@@ -88,64 +116,100 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				Computed:    true,
 			},
 			"ip_address": schema.StringAttribute{
-				Description: "The IP address of the device.",
+				CustomType:  IPAddressType{},
+				Description: "The IPv4 or IPv6 address of the device, or its DNS hostname when allow_hostname is true. IPv6 addresses are compared by value, so any textual form can be used.",
 				Required:    true,
 			},
+			"allow_hostname": schema.BoolAttribute{
+				Description: "Whether ip_address may be a DNS hostname, for devices polled by name. Defaults to false, which requires an IP address.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"description": schema.StringAttribute{
 				Description: "A description of the device.",
 				Optional:    true,
 			},
 			"monitoring_enabled": schema.BoolAttribute{
-				Description: "Whether monitoring is enabled for this device.",
+				Description: "Whether monitoring is enabled for this device. Defaults to the provider's device_defaults, or true.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
 			"snmp_enabled": schema.BoolAttribute{
-				Description: "Whether SNMP polling is enabled for this device.",
+				Description: "Whether SNMP polling is enabled for this device. Defaults to the provider's device_defaults, or true.",
 				Optional:    true,
 				Computed:    true,
 				Default:     booldefault.StaticBool(true),
 			},
 			"snmp_version": schema.StringAttribute{
-				Description: "The SNMP version to use (1, 2c, or 3).",
+				Description: "The SNMP version to use (1, 2c, or 3). Defaults to the provider's device_defaults, or 2c.",
 				Optional:    true,
 				Computed:    true,
 				Default:     stringdefault.StaticString("2c"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(snmpVersions...),
+				},
 			},
 			"snmp_port": schema.Int64Attribute{
-				Description: "The SNMP port to use.",
+				Description: "The SNMP port to use, between 1 and 65535. Defaults to the provider's device_defaults, or 161.",
 				Optional:    true,
 				Computed:    true,
 				Default:     int64default.StaticInt64(161),
+				Validators: []validator.Int64{
+					int64validator.Between(snmpPortMin, snmpPortMax),
+				},
 			},
 			"snmpv3_security_level": schema.StringAttribute{
-				Description: "SNMPv3 security level (noAuthNoPriv, authNoPriv, or authPriv). Only used when snmp_version is '3'.",
+				Description: "SNMPv3 security level (noAuthNoPriv, authNoPriv, or authPriv). Only allowed when snmp_version is '3'. authNoPriv requires the auth protocol and password, and authPriv also requires the priv protocol and password. Defaults to the provider's device_defaults when unset.",
 				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(snmpv3SecurityLevels...),
+				},
 			},
 			"snmpv3_username": schema.StringAttribute{
-				Description: "SNMPv3 username. Only used when snmp_version is '3'.",
+				Description: "SNMPv3 username. Only used when snmp_version is '3'. Defaults to the provider's device_defaults when unset.",
 				Optional:    true,
+				Computed:    true,
 			},
 			"snmpv3_auth_protocol": schema.StringAttribute{
-				Description: "SNMPv3 authentication protocol (MD5, SHA, SHA-224, SHA-256, SHA-384, SHA-512). Only used when snmp_version is '3'.",
+				Description: "SNMPv3 authentication protocol (MD5, SHA, SHA-224, SHA-256, SHA-384, SHA-512). Only used when snmp_version is '3'. Defaults to the provider's device_defaults when unset.",
 				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(snmpv3AuthProtocols...),
+				},
 			},
 			"snmpv3_auth_password": schema.StringAttribute{
-				Description: "SNMPv3 authentication password. Only used when snmp_version is '3'.",
+				Description: "SNMPv3 authentication password. Only used when snmp_version is '3'. Defaults to the provider's device_defaults when unset.",
 				Optional:    true,
+				Computed:    true,
 				Sensitive:   true,
 			},
 			"snmpv3_priv_protocol": schema.StringAttribute{
-				Description: "SNMPv3 privacy protocol (DES, AES, AES-192, AES-256). Only used when snmp_version is '3'.",
+				Description: "SNMPv3 privacy protocol (DES, AES, AES-192, AES-256). Only used when snmp_version is '3'. Defaults to the provider's device_defaults when unset.",
 				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(snmpv3PrivProtocols...),
+				},
 			},
 			"snmpv3_priv_password": schema.StringAttribute{
-				Description: "SNMPv3 privacy password. Only used when snmp_version is '3'.",
+				Description: "SNMPv3 privacy password. Only used when snmp_version is '3'. Defaults to the provider's device_defaults when unset.",
 				Optional:    true,
+				Computed:    true,
 				Sensitive:   true,
 			},
+			"snmpv3_auth_password_wo":         writeOnlyAttribute("snmpv3_auth_password", "SNMPv3 authentication password."),
+			"snmpv3_auth_password_wo_version": writeOnlyVersionAttribute("snmpv3_auth_password"),
+			"snmpv3_priv_password_wo":         writeOnlyAttribute("snmpv3_priv_password", "SNMPv3 privacy password."),
+			"snmpv3_priv_password_wo_version": writeOnlyVersionAttribute("snmpv3_priv_password"),
+			"deletion_protection":             deletionProtectionAttribute(),
+			"labels":                          labelsAttribute(),
+			"labels_all":                      labelsAllAttribute(),
 			"inserted_at": schema.StringAttribute{
+				CustomType:  TimestampType{},
 				Description: "The timestamp when the device was created.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
@@ -156,6 +220,12 @@ func (r *DeviceResource) Schema(ctx context.Context, req resource.SchemaRequest,
 	}
 }
 
+func (r *DeviceResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r,
+		upgradeFromV0,
+	)
+}
+
 func (r *DeviceResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -173,6 +243,214 @@ func (r *DeviceResource) Configure(ctx context.Context, req resource.ConfigureRe
 	r.client = client
 }
 
+func (r *DeviceResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		snmpv3VersionValidator{},
+		snmpv3SecurityLevelValidator{},
+	}
+}
+
+func (r *DeviceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateWriteOnlyConfig(ctx, req.Config, "snmpv3_auth_password")...)
+	resp.Diagnostics.Append(validateWriteOnlyConfig(ctx, req.Config, "snmpv3_priv_password")...)
+	resp.Diagnostics.Append(validateDeviceAddress(ctx, req.Config)...)
+}
+
+// validateDeviceAddress rejects a hostname in ip_address unless
+// allow_hostname is set. The IPAddressType already rejects values that are
+// neither an address nor a hostname.
+func validateDeviceAddress(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var address IPAddressValue
+	var allowHostname types.Bool
+	diags.Append(config.GetAttribute(ctx, path.Root("ip_address"), &address)...)
+	diags.Append(config.GetAttribute(ctx, path.Root("allow_hostname"), &allowHostname)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if address.IsNull() || address.IsUnknown() || address.IsIP() || allowHostname.IsUnknown() || allowHostname.ValueBool() {
+		return diags
+	}
+
+	diags.AddAttributeError(
+		path.Root("ip_address"),
+		"Invalid IP Address",
+		fmt.Sprintf("%q is not an IPv4 or IPv6 address. Set allow_hostname = true to poll the device by DNS name.", address.ValueString()),
+	)
+
+	return diags
+}
+
+func (r *DeviceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the device is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var defaults DeviceDefaults
+	if r.client != nil {
+		defaults = r.client.DeviceDefaults
+	}
+
+	applyDeviceDefaults(ctx, defaults, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateDeviceSNMPPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateDeviceUseSites(ctx, r.client, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	planDeviceMove(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modifyPlanDeletionProtection(ctx, r.client, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modifyPlanLabels(ctx, r.client, req, resp)
+}
+
+// applyDeviceDefaults fills in attributes the configuration leaves unset from
+// the provider's device_defaults, so the plan shows the values that will
+// actually be sent to the API.
+func applyDeviceDefaults(ctx context.Context, defaults DeviceDefaults, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var config, plan DeviceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.MonitoringEnabled.IsNull() && defaults.MonitoringEnabled != nil {
+		plan.MonitoringEnabled = types.BoolPointerValue(defaults.MonitoringEnabled)
+	}
+	if config.SNMPEnabled.IsNull() && defaults.SNMPEnabled != nil {
+		plan.SNMPEnabled = types.BoolPointerValue(defaults.SNMPEnabled)
+	}
+	if config.SNMPVersion.IsNull() && defaults.SNMPVersion != nil {
+		plan.SNMPVersion = types.StringPointerValue(defaults.SNMPVersion)
+	}
+	if config.SNMPPort.IsNull() && defaults.SNMPPort != nil {
+		plan.SNMPPort = types.Int64PointerValue(defaults.SNMPPort)
+	}
+
+	// SNMPv3 defaults only apply to devices that end up using SNMPv3.
+	applyV3Default := func(configured types.String, planned *types.String, value *string) {
+		if !configured.IsNull() {
+			return
+		}
+		switch {
+		case plan.SNMPVersion.IsUnknown():
+			*planned = types.StringUnknown()
+		case plan.SNMPVersion.ValueString() == "3":
+			*planned = types.StringPointerValue(value)
+		default:
+			*planned = types.StringNull()
+		}
+	}
+
+	applyV3Default(config.SNMPv3SecurityLevel, &plan.SNMPv3SecurityLevel, defaults.SNMPv3SecurityLevel)
+	applyV3Default(config.SNMPv3Username, &plan.SNMPv3Username, defaults.SNMPv3Username)
+	applyV3Default(config.SNMPv3AuthProtocol, &plan.SNMPv3AuthProtocol, defaults.SNMPv3AuthProtocol)
+	applyV3Default(config.SNMPv3PrivProtocol, &plan.SNMPv3PrivProtocol, defaults.SNMPv3PrivProtocol)
+
+	// Passwords managed through write-only attributes never appear in the plan.
+	if config.SNMPv3AuthPasswordWOVersion.IsNull() {
+		applyV3Default(config.SNMPv3AuthPassword, &plan.SNMPv3AuthPassword, defaults.SNMPv3AuthPassword)
+	} else {
+		plan.SNMPv3AuthPassword = types.StringNull()
+	}
+	if config.SNMPv3PrivPasswordWOVersion.IsNull() {
+		applyV3Default(config.SNMPv3PrivPassword, &plan.SNMPv3PrivPassword, defaults.SNMPv3PrivPassword)
+	} else {
+		plan.SNMPv3PrivPassword = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// requiresReplaceIfOrganizationChanged replaces a device only when it would
+// move from one organization to another. The API leaves organization_id
+// empty for devices at a site, so setting it when a device leaves its site,
+// or dropping it when the device joins one, is not a change of organization.
+func requiresReplaceIfOrganizationChanged(ctx context.Context, req planmodifier.StringRequest, resp *stringplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = !req.StateValue.IsNull() && !req.StateValue.IsUnknown() &&
+		!req.PlanValue.IsNull() && !req.PlanValue.IsUnknown() &&
+		!req.PlanValue.Equal(req.StateValue)
+}
+
+// deviceRejectedMoveKey is the private state key holding the site_id of a
+// move the API rejected, as JSON.
+const deviceRejectedMoveKey = "rejected_move"
+
+// planDeviceMove plans moves of an existing device between sites. Removing
+// site_id from the configuration plans it as null, so the device moves out of
+// its site rather than keeping the value the API last returned. Moves are
+// planned in place; one that the API rejected on an earlier apply is planned
+// as a replacement instead.
+func planDeviceMove(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// New devices keep the site the API assigns.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var configSiteID, configOrgID, planSiteID, stateSiteID, deviceID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("site_id"), &configSiteID)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("organization_id"), &configOrgID)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("site_id"), &planSiteID)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("site_id"), &stateSiteID)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &deviceID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if configSiteID.IsNull() {
+		planSiteID = types.StringNull()
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("site_id"), planSiteID)...)
+	}
+
+	if planSiteID.IsUnknown() || planSiteID.Equal(stateSiteID) {
+		return
+	}
+
+	// The API reports organization_id only for devices outside a site, so
+	// an unset organization_id is not known until the move is made.
+	if configOrgID.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("organization_id"), types.StringUnknown())...)
+	}
+
+	rejected, diags := req.Private.GetKey(ctx, deviceRejectedMoveKey)
+	resp.Diagnostics.Append(diags...)
+	planned, err := json.Marshal(planSiteID.ValueStringPointer())
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to plan device move", err.Error())
+		return
+	}
+	if rejected == nil || !bytes.Equal(rejected, planned) {
+		return
+	}
+
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("site_id"))
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("site_id"),
+		"Device Will Be Replaced",
+		fmt.Sprintf("TowerOps rejected the move of device %s to this site on the last apply, so it will be replaced. The new device gets a new ID and starts without the old device's monitoring history.", deviceID.ValueString()),
+	)
+}
+
 func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data DeviceResourceModel
 
@@ -256,6 +534,18 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 		device.SNMPv3PrivPassword = &password
 	}
 
+	resp.Diagnostics.Append(applyDeviceWriteOnlySecrets(ctx, req.Config, nil, &device)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	device.Labels = labels
+
 	created, err := r.client.CreateDevice(device)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create device", err.Error())
@@ -263,7 +553,8 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	data.ID = types.StringValue(created.ID)
-	data.InsertedAt = types.StringValue(created.InsertedAt)
+	data.InsertedAt = NewTimestampValue(created.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, created.Labels)...)
 
 	if created.SiteID != nil {
 		data.SiteID = types.StringValue(*created.SiteID)
@@ -288,7 +579,9 @@ func (r *DeviceResource) Create(ctx context.Context, req resource.CreateRequest,
 		data.SNMPEnabled = types.BoolValue(*created.SNMPEnabled)
 	}
 
+	clearDeviceWriteOnlySecrets(&data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -310,6 +603,20 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	setDeviceFieldsFromAPI(&data, device)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, device.Labels)...)
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(defaultDeletionProtection(r.client))
+	}
+
+	clearDeviceWriteOnlySecrets(&data)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
+}
+
+// setDeviceFieldsFromAPI maps an API device back to the Terraform model.
+// Labels are handled separately by setLabelsFromAPI.
+func setDeviceFieldsFromAPI(data *DeviceResourceModel, device *Device) {
 	if device.SiteID != nil {
 		data.SiteID = types.StringValue(*device.SiteID)
 	} else {
@@ -322,14 +629,18 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 		data.OrganizationID = types.StringNull()
 	}
 
-	data.IPAddress = types.StringValue(device.IPAddress)
+	data.IPAddress = NewIPAddressValue(device.IPAddress)
+	if data.AllowHostname.IsNull() {
+		// Imported devices get the value their address needs.
+		data.AllowHostname = types.BoolValue(!data.IPAddress.IsIP())
+	}
 
 	if device.Name != nil {
 		data.Name = types.StringValue(*device.Name)
 	} else {
 		data.Name = types.StringNull()
 	}
-	data.InsertedAt = types.StringValue(device.InsertedAt)
+	data.InsertedAt = NewTimestampValue(device.InsertedAt)
 
 	if device.Description != nil {
 		data.Description = types.StringValue(*device.Description)
@@ -389,14 +700,13 @@ func (r *DeviceResource) Read(ctx context.Context, req resource.ReadRequest, res
 	} else {
 		data.SNMPv3PrivPassword = types.StringNull()
 	}
-
-	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data DeviceResourceModel
+	var data, state DeviceResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -476,57 +786,59 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		device.SNMPv3PrivPassword = &password
 	}
 
-	updated, err := r.client.UpdateDevice(data.ID.ValueString(), device)
-	if err != nil {
-		if errors.Is(err, ErrNotFound) {
-			// Device was deleted outside of Terraform, recreate it
-			created, createErr := r.client.CreateDevice(device)
-			if createErr != nil {
-				resp.Diagnostics.AddError("Failed to create device (after 404 on update)", createErr.Error())
-				return
-			}
-			data.ID = types.StringValue(created.ID)
-			data.InsertedAt = types.StringValue(created.InsertedAt)
-			data.IPAddress = types.StringValue(created.IPAddress)
-
-			if created.SiteID != nil {
-				data.SiteID = types.StringValue(*created.SiteID)
-			} else {
-				data.SiteID = types.StringNull()
-			}
+	resp.Diagnostics.Append(applyDeviceWriteOnlySecrets(ctx, req.Config, &req.State, &device)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-			if created.OrganizationID != nil {
-				data.OrganizationID = types.StringValue(*created.OrganizationID)
-			} else {
-				data.OrganizationID = types.StringNull()
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	device.Labels = labels
+
+	// Moves between sites are sent on their own first, so a device the
+	// backend cannot move is reported as such before anything else changes.
+	if !data.SiteID.Equal(state.SiteID) {
+		_, err := r.client.MoveDevice(data.ID.ValueString(), device.SiteID)
+		switch {
+		case err == nil, errors.Is(err, ErrNotFound):
+			// A missing device is reported by the update below.
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, deviceRejectedMoveKey, nil)...)
+		case errors.Is(err, ErrConflict):
+			// The next plan replaces the device instead.
+			rejected, jsonErr := json.Marshal(device.SiteID)
+			if jsonErr == nil {
+				resp.Diagnostics.Append(resp.Private.SetKey(ctx, deviceRejectedMoveKey, rejected)...)
 			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root("site_id"),
+				"Failed to move device",
+				fmt.Sprintf("TowerOps cannot move the device to the new site, so it was left unchanged: %s\n\nRun terraform apply again to replace the device at the new site. The new device gets a new ID and starts without the old device's monitoring history.", err),
+			)
+			return
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("site_id"),
+				"Failed to move device",
+				fmt.Sprintf("The device could not be moved and was left unchanged: %s", err),
+			)
+			return
+		}
+	}
 
-			if created.Name != nil {
-				data.Name = types.StringValue(*created.Name)
-			}
-			if created.Description != nil {
-				data.Description = types.StringValue(*created.Description)
-			}
-			if created.MonitoringEnabled != nil {
-				data.MonitoringEnabled = types.BoolValue(*created.MonitoringEnabled)
-			}
-			if created.SNMPEnabled != nil {
-				data.SNMPEnabled = types.BoolValue(*created.SNMPEnabled)
-			}
-			if created.SNMPVersion != nil {
-				data.SNMPVersion = types.StringValue(*created.SNMPVersion)
-			}
-			if created.SNMPPort != nil {
-				data.SNMPPort = types.Int64Value(int64(*created.SNMPPort))
-			}
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	updated, err := r.client.UpdateDevice(data.ID.ValueString(), device)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			resp.Diagnostics.Append(deletedDuringUpdate("device", data.ID.ValueString()))
 			return
 		}
 		resp.Diagnostics.AddError("Failed to update device", err.Error())
 		return
 	}
 
-	data.IPAddress = types.StringValue(updated.IPAddress)
+	data.IPAddress = NewIPAddressValue(updated.IPAddress)
 
 	if updated.SiteID != nil {
 		data.SiteID = types.StringValue(*updated.SiteID)
@@ -596,7 +908,42 @@ func (r *DeviceResource) Update(ctx context.Context, req resource.UpdateRequest,
 		data.SNMPv3PrivPassword = types.StringNull()
 	}
 
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, updated.Labels)...)
+
+	clearDeviceWriteOnlySecrets(&data)
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
+}
+
+// applyDeviceWriteOnlySecrets sets passwords managed through write-only
+// attributes on the API request. state is nil when the device is created.
+func applyDeviceWriteOnlySecrets(ctx context.Context, config tfsdk.Config, state *tfsdk.State, device *Device) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	authPassword, d := writeOnlySecret(ctx, config, state, "snmpv3_auth_password")
+	diags.Append(d...)
+	if authPassword != nil {
+		device.SNMPv3AuthPassword = authPassword
+	}
+
+	privPassword, d := writeOnlySecret(ctx, config, state, "snmpv3_priv_password")
+	diags.Append(d...)
+	if privPassword != nil {
+		device.SNMPv3PrivPassword = privPassword
+	}
+
+	return diags
+}
+
+// clearDeviceWriteOnlySecrets keeps passwords managed through write-only
+// attributes out of state, even when the API echoes them back.
+func clearDeviceWriteOnlySecrets(data *DeviceResourceModel) {
+	if !data.SNMPv3AuthPasswordWOVersion.IsNull() {
+		data.SNMPv3AuthPassword = types.StringNull()
+	}
+	if !data.SNMPv3PrivPasswordWOVersion.IsNull() {
+		data.SNMPv3PrivPassword = types.StringNull()
+	}
 }
 
 func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -607,6 +954,11 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	resp.Diagnostics.Append(checkDeletionProtection(data.DeletionProtection, "device", data.ID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	err := r.client.DeleteDevice(data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to delete device", err.Error())
@@ -615,5 +967,14 @@ func (r *DeviceResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *DeviceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if key, ok := parseImportKey(req.ID); ok {
+		id, err := resolveDeviceImportKey(r.client, key)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot Import Device", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	importStateWithIdentity(ctx, r.client, req, resp)
 }
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -9,7 +10,19 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestAccDeviceResource_basic(t *testing.T) {
@@ -416,11 +429,285 @@ func TestAccDeviceResource_importState(t *testing.T) {
 	})
 }
 
+func TestAccDeviceResource_moveSite(t *testing.T) {
+	var mu sync.Mutex
+	var siteID *string
+	creates, moves := 0, 0
+
+	// Like the API, the fixture reports organization_id only for devices
+	// outside a site.
+	device := func() Device {
+		d := Device{
+			ID:         "test-device-id",
+			SiteID:     siteID,
+			IPAddress:  "192.168.1.1",
+			InsertedAt: "2024-01-01T00:00:00Z",
+		}
+		if siteID == nil {
+			d.OrganizationID = strPtr("org-1")
+		}
+		return d
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/devices":
+			creates++
+			siteID = strPtr("site-123")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(device())
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/devices/test-device-id":
+			json.NewEncoder(w).Encode(device())
+
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/devices/test-device-id":
+			var body map[string]map[string]json.RawMessage
+			json.NewDecoder(r.Body).Decode(&body)
+			if raw, ok := body["device"]["site_id"]; ok {
+				if _, isUpdate := body["device"]["ip_address"]; !isUpdate {
+					moves++
+				}
+				siteID = nil
+				json.Unmarshal(raw, &siteID)
+			}
+			json.NewEncoder(w).Encode(device())
+
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/devices/test-device-id":
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(server.URL),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDeviceResourceConfig(server.URL, "site-123", "192.168.1.1"),
+				Check:  resource.TestCheckResourceAttr("towerops_device.test", "site_id", "site-123"),
+			},
+			{
+				Config: testAccDeviceResourceConfig(server.URL, "site-456", "192.168.1.1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("towerops_device.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("towerops_device.test", "id", "test-device-id"),
+					resource.TestCheckResourceAttr("towerops_device.test", "site_id", "site-456"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "towerops" {
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
+}
+
+resource "towerops_device" "test" {
+  organization_id = "org-1"
+  ip_address      = "192.168.1.1"
+}
+`, server.URL),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("towerops_device.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("towerops_device.test", tfjsonpath.New("site_id"), knownvalue.Null()),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("towerops_device.test", "id", "test-device-id"),
+					resource.TestCheckNoResourceAttr("towerops_device.test", "site_id"),
+					func(*terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+						if creates != 1 || moves != 2 {
+							return fmt.Errorf("expected 1 create and 2 moves, got %d and %d", creates, moves)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestDeviceResource_ModifyPlan_moveOutOfSite(t *testing.T) {
+	ctx := context.Background()
+	r := &DeviceResource{}
+
+	req, resp := newDeviceModifyPlanRequest(t, DeviceResourceModel{
+		OrganizationID: types.StringValue("org-1"),
+		IPAddress:      NewIPAddressValue("10.0.0.1"),
+	})
+	resp.Plan.SetAttribute(ctx, path.Root("site_id"), types.StringUnknown())
+	req.Plan = resp.Plan
+
+	// A new device keeps the site the API assigns.
+	r.ModifyPlan(ctx, req, resp)
+	var siteID types.String
+	resp.Plan.GetAttribute(ctx, path.Root("site_id"), &siteID)
+	if !siteID.IsUnknown() {
+		t.Errorf("expected site_id unknown on create, got %s", siteID)
+	}
+
+	// An existing device with site_id removed from its configuration moves
+	// out of its site.
+	req.State = tfsdk.State{Schema: req.Plan.Schema, Raw: req.Plan.Raw.Copy()}
+	req.State.SetAttribute(ctx, path.Root("id"), types.StringValue("device-1"))
+	req.State.SetAttribute(ctx, path.Root("site_id"), types.StringValue("site-123"))
+	r.ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	resp.Plan.GetAttribute(ctx, path.Root("site_id"), &siteID)
+	if !siteID.IsNull() {
+		t.Errorf("expected site_id null, got %s", siteID)
+	}
+}
+
+func TestDeviceResource_rejectedMove(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/organization":
+			w.Write([]byte(`{"data": {"id": "org-1", "name": "Test ISP", "use_sites": true}}`))
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/devices/device-1":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error": "device cannot move"}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	provider, schemaResp := newConfiguredProviderServer(t, server.URL, nil)
+	objectType := schemaResp.ResourceSchemas["towerops_device"].ValueType().(tftypes.Object)
+
+	device := func(siteID string) map[string]tftypes.Value {
+		return map[string]tftypes.Value{
+			"id":                  tfString("device-1"),
+			"site_id":             tfString(siteID),
+			"ip_address":          tfString("10.0.0.1"),
+			"allow_hostname":      tftypes.NewValue(tftypes.Bool, false),
+			"monitoring_enabled":  tftypes.NewValue(tftypes.Bool, true),
+			"snmp_enabled":        tftypes.NewValue(tftypes.Bool, true),
+			"snmp_version":        tfString("2c"),
+			"snmp_port":           tftypes.NewValue(tftypes.Number, 161),
+			"deletion_protection": tftypes.NewValue(tftypes.Bool, false),
+			"labels_all":          tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{}),
+			"inserted_at":         tfString("2024-01-01T00:00:00Z"),
+		}
+	}
+	prior := newDynamicValue(t, objectType, device("site-123"))
+	moved := newDynamicValue(t, objectType, device("site-456"))
+	config := newDynamicValue(t, objectType, map[string]tftypes.Value{
+		"site_id":    tfString("site-456"),
+		"ip_address": tfString("10.0.0.1"),
+	})
+
+	plan := func(priorPrivate []byte) *tfprotov6.PlanResourceChangeResponse {
+		t.Helper()
+		resp, err := provider.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+			TypeName:         "towerops_device",
+			PriorState:       prior,
+			ProposedNewState: moved,
+			Config:           config,
+			PriorPrivate:     priorPrivate,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, d := range resp.Diagnostics {
+			if d.Severity == tfprotov6.DiagnosticSeverityError {
+				t.Fatalf("unexpected error: %s: %s", d.Summary, d.Detail)
+			}
+		}
+		return resp
+	}
+
+	// Moves are planned in place without asking the API.
+	planResp := plan(nil)
+	if len(planResp.RequiresReplace) > 0 {
+		t.Fatalf("expected the move to be planned in place, got RequiresReplace %v", planResp.RequiresReplace)
+	}
+
+	applyResp, err := provider.ApplyResourceChange(ctx, &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       "towerops_device",
+		PriorState:     prior,
+		PlannedState:   planResp.PlannedState,
+		Config:         config,
+		PlannedPrivate: planResp.PlannedPrivate,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var errs []string
+	for _, d := range applyResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			errs = append(errs, d.Summary)
+		}
+	}
+	if len(errs) != 1 || errs[0] != "Failed to move device" {
+		t.Fatalf("expected a move error, got: %v", errs)
+	}
+
+	// The next plan replaces the device at the site the API rejected.
+	planResp = plan(applyResp.Private)
+	if len(planResp.RequiresReplace) != 1 || planResp.RequiresReplace[0].String() != `AttributeName("site_id")` {
+		t.Errorf("expected site_id to require replacement, got %v", planResp.RequiresReplace)
+	}
+	var warnings []string
+	for _, d := range planResp.Diagnostics {
+		warnings = append(warnings, d.Summary)
+	}
+	if len(warnings) != 1 || warnings[0] != "Device Will Be Replaced" {
+		t.Errorf("expected a replacement warning, got: %v", warnings)
+	}
+}
+
+func TestRequiresReplaceIfOrganizationChanged(t *testing.T) {
+	tests := []struct {
+		name        string
+		state, plan types.String
+		want        bool
+	}{
+		{name: "unchanged", state: types.StringValue("org-1"), plan: types.StringValue("org-1")},
+		{name: "changed", state: types.StringValue("org-1"), plan: types.StringValue("org-2"), want: true},
+		{name: "leaving a site", state: types.StringNull(), plan: types.StringValue("org-1")},
+		{name: "joining a site", state: types.StringValue("org-1"), plan: types.StringNull()},
+		{name: "unknown", state: types.StringValue("org-1"), plan: types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &stringplanmodifier.RequiresReplaceIfFuncResponse{}
+			requiresReplaceIfOrganizationChanged(context.Background(), planmodifier.StringRequest{
+				StateValue: tt.state,
+				PlanValue:  tt.plan,
+			}, resp)
+			if resp.RequiresReplace != tt.want {
+				t.Errorf("expected RequiresReplace %t, got %t", tt.want, resp.RequiresReplace)
+			}
+		})
+	}
+}
+
 func testAccDeviceResourceConfig(apiURL, siteID, ipAddress string) string {
 	return fmt.Sprintf(`
 provider "towerops" {
-  token   = "test-token"
-  api_url = %q
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
 }
 
 resource "towerops_device" "test" {
@@ -433,8 +720,9 @@ resource "towerops_device" "test" {
 func testAccDeviceResourceConfigFull(apiURL string) string {
 	return fmt.Sprintf(`
 provider "towerops" {
-  token   = "test-token"
-  api_url = %q
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
 }
 
 resource "towerops_device" "test" {
@@ -720,3 +1008,137 @@ func TestAccDeviceResource_recreateOn404_createError(t *testing.T) {
 		},
 	})
 }
+
+// newDeviceModifyPlanRequest builds a ModifyPlan request for a device being
+// created, with the schema's static defaults already applied to the plan.
+func newDeviceModifyPlanRequest(t *testing.T, config DeviceResourceModel) (fwresource.ModifyPlanRequest, *fwresource.ModifyPlanResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewDeviceResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	config.Labels = types.MapNull(types.StringType)
+	config.LabelsAll = types.MapNull(types.StringType)
+	configState := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := configState.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("unexpected error building config: %v", diags)
+	}
+
+	planned := config
+	planned.ID = types.StringUnknown()
+	planned.InsertedAt = NewTimestampUnknown()
+	planned.LabelsAll = types.MapUnknown(types.StringType)
+	if planned.MonitoringEnabled.IsNull() {
+		planned.MonitoringEnabled = types.BoolValue(true)
+	}
+	if planned.SNMPEnabled.IsNull() {
+		planned.SNMPEnabled = types.BoolValue(true)
+	}
+	if planned.SNMPVersion.IsNull() {
+		planned.SNMPVersion = types.StringValue("2c")
+	}
+	if planned.SNMPPort.IsNull() {
+		planned.SNMPPort = types.Int64Value(161)
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, &planned); diags.HasError() {
+		t.Fatalf("unexpected error building plan: %v", diags)
+	}
+
+	req := fwresource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: configState.Raw},
+		Plan:   plan,
+		State:  tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(plan.Raw.Type(), nil)},
+	}
+	resp := &fwresource.ModifyPlanResponse{Plan: plan}
+
+	return req, resp
+}
+
+func TestDeviceResource_ModifyPlan_deviceDefaults(t *testing.T) {
+	ctx := context.Background()
+	version := "3"
+	port := int64(1161)
+	monitoring := false
+	level := "noAuthNoPriv"
+	username := "monitor"
+
+	r := &DeviceResource{client: &Client{org: &Organization{}, DeviceDefaults: DeviceDefaults{
+		MonitoringEnabled:   &monitoring,
+		SNMPVersion:         &version,
+		SNMPPort:            &port,
+		SNMPv3SecurityLevel: &level,
+		SNMPv3Username:      &username,
+	}}}
+
+	req, resp := newDeviceModifyPlanRequest(t, DeviceResourceModel{
+		IPAddress: NewIPAddressValue("10.0.0.1"),
+	})
+	r.ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var plan DeviceResourceModel
+	resp.Plan.Get(ctx, &plan)
+
+	if plan.SNMPVersion.ValueString() != "3" {
+		t.Errorf("expected snmp_version 3, got %s", plan.SNMPVersion)
+	}
+	if plan.SNMPPort.ValueInt64() != 1161 {
+		t.Errorf("expected snmp_port 1161, got %s", plan.SNMPPort)
+	}
+	if plan.MonitoringEnabled.ValueBool() {
+		t.Error("expected monitoring_enabled false")
+	}
+	if !plan.SNMPEnabled.ValueBool() {
+		t.Error("expected snmp_enabled to keep its schema default of true")
+	}
+	if plan.SNMPv3SecurityLevel.ValueString() != "noAuthNoPriv" {
+		t.Errorf("expected snmpv3_security_level noAuthNoPriv, got %s", plan.SNMPv3SecurityLevel)
+	}
+	if plan.SNMPv3Username.ValueString() != "monitor" {
+		t.Errorf("expected snmpv3_username monitor, got %s", plan.SNMPv3Username)
+	}
+	if !plan.SNMPv3AuthProtocol.IsNull() {
+		t.Errorf("expected snmpv3_auth_protocol null, got %s", plan.SNMPv3AuthProtocol)
+	}
+}
+
+func TestDeviceResource_ModifyPlan_configOverridesDeviceDefaults(t *testing.T) {
+	ctx := context.Background()
+	version := "3"
+	port := int64(1161)
+	username := "monitor"
+
+	r := &DeviceResource{client: &Client{org: &Organization{}, DeviceDefaults: DeviceDefaults{
+		SNMPVersion:    &version,
+		SNMPPort:       &port,
+		SNMPv3Username: &username,
+	}}}
+
+	req, resp := newDeviceModifyPlanRequest(t, DeviceResourceModel{
+		IPAddress:   NewIPAddressValue("10.0.0.1"),
+		SNMPVersion: types.StringValue("2c"),
+		SNMPPort:    types.Int64Value(161),
+	})
+	r.ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var plan DeviceResourceModel
+	resp.Plan.Get(ctx, &plan)
+
+	if plan.SNMPVersion.ValueString() != "2c" {
+		t.Errorf("expected snmp_version 2c, got %s", plan.SNMPVersion)
+	}
+	if plan.SNMPPort.ValueInt64() != 161 {
+		t.Errorf("expected snmp_port 161, got %s", plan.SNMPPort)
+	}
+	// SNMPv3 defaults must not leak onto a v2c device.
+	if !plan.SNMPv3Username.IsNull() {
+		t.Errorf("expected snmpv3_username null, got %s", plan.SNMPv3Username)
+	}
+}
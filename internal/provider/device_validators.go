@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Allowed values for the SNMP settings of devices and device_defaults.
+var (
+	snmpVersions                   = []string{"1", "2c", "3"}
+	snmpv3SecurityLevels           = []string{"noAuthNoPriv", "authNoPriv", "authPriv"}
+	snmpv3AuthProtocols            = []string{"MD5", "SHA", "SHA-224", "SHA-256", "SHA-384", "SHA-512"}
+	snmpv3PrivProtocols            = []string{"DES", "AES", "AES-192", "AES-256"}
+	snmpPortMin, snmpPortMax int64 = 1, 65535
+)
+
+// snmpv3Attributes are the device attributes that only apply to SNMPv3.
+var snmpv3Attributes = []string{
+	"snmpv3_security_level",
+	"snmpv3_username",
+	"snmpv3_auth_protocol",
+	"snmpv3_auth_password",
+	"snmpv3_auth_password_wo",
+	"snmpv3_priv_protocol",
+	"snmpv3_priv_password",
+	"snmpv3_priv_password_wo",
+}
+
+// snmpv3Forbidden lists, per security level, the attributes that level
+// doesn't use.
+var snmpv3Forbidden = map[string][]string{
+	"noAuthNoPriv": {
+		"snmpv3_auth_protocol",
+		"snmpv3_auth_password",
+		"snmpv3_auth_password_wo",
+		"snmpv3_priv_protocol",
+		"snmpv3_priv_password",
+		"snmpv3_priv_password_wo",
+	},
+	"authNoPriv": {
+		"snmpv3_priv_protocol",
+		"snmpv3_priv_password",
+		"snmpv3_priv_password_wo",
+	},
+}
+
+var _ resource.ConfigValidator = snmpv3VersionValidator{}
+var _ resource.ConfigValidator = snmpv3SecurityLevelValidator{}
+
+// snmpv3VersionValidator rejects SNMPv3 attributes on a device configured
+// with another snmp_version.
+type snmpv3VersionValidator struct{}
+
+func (v snmpv3VersionValidator) Description(ctx context.Context) string {
+	return `SNMPv3 attributes can only be set when snmp_version is "3"`
+}
+
+func (v snmpv3VersionValidator) MarkdownDescription(ctx context.Context) string {
+	return "SNMPv3 attributes can only be set when `snmp_version` is `\"3\"`"
+}
+
+func (v snmpv3VersionValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var version types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("snmp_version"), &version)...)
+	if resp.Diagnostics.HasError() || version.IsNull() || version.IsUnknown() || version.ValueString() == "3" {
+		return
+	}
+
+	for _, name := range configuredAttributes(ctx, req.Config, snmpv3Attributes, &resp.Diagnostics) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Invalid SNMP Configuration",
+			fmt.Sprintf("%s can only be set when snmp_version is \"3\", but snmp_version is %q.", name, version.ValueString()),
+		)
+	}
+}
+
+// snmpv3SecurityLevelValidator rejects passwords and protocols that the
+// configured snmpv3_security_level doesn't use. Required settings are
+// checked when planning, since they may come from the provider's
+// device_defaults.
+type snmpv3SecurityLevelValidator struct{}
+
+func (v snmpv3SecurityLevelValidator) Description(ctx context.Context) string {
+	return "noAuthNoPriv forbids authentication and privacy settings, and authNoPriv forbids privacy settings"
+}
+
+func (v snmpv3SecurityLevelValidator) MarkdownDescription(ctx context.Context) string {
+	return "`noAuthNoPriv` forbids authentication and privacy settings, and `authNoPriv` forbids privacy settings"
+}
+
+func (v snmpv3SecurityLevelValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var level types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("snmpv3_security_level"), &level)...)
+	if resp.Diagnostics.HasError() || level.IsNull() || level.IsUnknown() {
+		return
+	}
+
+	for _, name := range configuredAttributes(ctx, req.Config, snmpv3Forbidden[level.ValueString()], &resp.Diagnostics) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(name),
+			"Invalid SNMP Configuration",
+			fmt.Sprintf("%s cannot be set when snmpv3_security_level is %q.", name, level.ValueString()),
+		)
+	}
+}
+
+// configuredAttributes returns the names of the string attributes that the
+// configuration sets, including to unknown values.
+func configuredAttributes(ctx context.Context, config tfsdk.Config, names []string, diags *diag.Diagnostics) []string {
+	var configured []string
+	for _, name := range names {
+		var value types.String
+		diags.Append(config.GetAttribute(ctx, path.Root(name), &value)...)
+		if !value.IsNull() {
+			configured = append(configured, name)
+		}
+	}
+	return configured
+}
+
+// validateDeviceSNMPPlan checks that the effective SNMP settings, after the
+// provider's device_defaults are applied, are complete. It runs when
+// planning, since the configuration alone can't tell what the defaults
+// supply.
+func validateDeviceSNMPPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	var config, plan DeviceResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// SNMPv3 attributes set on a device that ends up on another version,
+	// through the schema default or device_defaults.
+	if !plan.SNMPVersion.IsUnknown() && plan.SNMPVersion.ValueString() != "3" {
+		for _, name := range configuredAttributes(ctx, req.Config, snmpv3Attributes, &resp.Diagnostics) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(name),
+				"Invalid SNMP Configuration",
+				fmt.Sprintf("%s can only be set when snmp_version is \"3\", but this device uses snmp_version %q. Set snmp_version = \"3\".", name, plan.SNMPVersion.ValueString()),
+			)
+		}
+		return
+	}
+
+	if plan.SNMPv3SecurityLevel.IsUnknown() {
+		return
+	}
+
+	type setting struct {
+		name string
+		set  bool
+	}
+	authProtocol := setting{"snmpv3_auth_protocol", !plan.SNMPv3AuthProtocol.IsNull()}
+	authPassword := setting{"snmpv3_auth_password", !plan.SNMPv3AuthPassword.IsNull() || !config.SNMPv3AuthPasswordWOVersion.IsNull()}
+	privProtocol := setting{"snmpv3_priv_protocol", !plan.SNMPv3PrivProtocol.IsNull()}
+	privPassword := setting{"snmpv3_priv_password", !plan.SNMPv3PrivPassword.IsNull() || !config.SNMPv3PrivPasswordWOVersion.IsNull()}
+
+	var required []setting
+	switch plan.SNMPv3SecurityLevel.ValueString() {
+	case "authNoPriv":
+		required = []setting{authProtocol, authPassword}
+	case "authPriv":
+		required = []setting{authProtocol, authPassword, privProtocol, privPassword}
+	}
+
+	var missing []string
+	for _, s := range required {
+		if !s.set {
+			missing = append(missing, s.name)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		path.Root("snmpv3_security_level"),
+		"Incomplete SNMP Configuration",
+		fmt.Sprintf("snmpv3_security_level %q also requires %s. Set them on the device, or in the provider's device_defaults. Passwords can also be set with the _wo attributes.", plan.SNMPv3SecurityLevel.ValueString(), strings.Join(missing, ", ")),
+	)
+}
+
+// validateDeviceUseSites checks the device's site_id against the
+// organization's use_sites setting: organizations that use sites need every
+// device in a site, and the others can't have devices in sites. A use_sites
+// value planned by towerops_organization earlier in the run takes precedence
+// over the organization's current setting. A mismatch is an error, except
+// when a towerops_organization has been seen in this run but not planned
+// yet: it may still change the setting, so that case is only a warning and
+// the API rejects the device on apply if it still doesn't fit.
+func validateDeviceUseSites(ctx context.Context, client *Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if client == nil {
+		return
+	}
+
+	var siteID types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("site_id"), &siteID)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var useSites bool
+	source := "The organization"
+	planned := client.PlannedUseSites()
+	if planned != nil && planned.Changing {
+		if !planned.Known {
+			return
+		}
+		useSites = planned.Value
+		source = "towerops_organization changes use_sites in this plan, so the organization"
+	} else {
+		org, err := client.CurrentOrganization()
+		if err != nil {
+			// The API checks this again on apply, so plans keep working
+			// offline.
+			tflog.Debug(ctx, "Skipping use_sites check for device", map[string]any{
+				"error": err.Error(),
+			})
+			return
+		}
+		useSites = org.UseSites
+	}
+
+	var summary, detail string
+	switch {
+	case useSites && siteID.IsNull():
+		summary = "Device Requires a Site"
+		detail = fmt.Sprintf("%s uses sites (use_sites = true), so every device must belong to a site. Set site_id, or set use_sites = false on towerops_organization to assign devices directly to the organization.", source)
+	case !useSites && !siteID.IsNull():
+		summary = "Organization Does Not Use Sites"
+		detail = fmt.Sprintf("%s does not use sites (use_sites = false), so devices belong directly to the organization. Remove site_id, or set use_sites = true on towerops_organization to group devices into sites.", source)
+	default:
+		return
+	}
+
+	// Once towerops_organization has been planned, its use_sites value is
+	// final for this run.
+	if planned != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("site_id"), summary, detail)
+		return
+	}
+
+	dependsOn := " If towerops_organization changes use_sites in this configuration, add it to this device's depends_on so the change is planned and applied first."
+	if client.OrganizationManaged() {
+		resp.Diagnostics.AddAttributeWarning(path.Root("site_id"), summary, detail+dependsOn+" Otherwise the apply will fail.")
+		return
+	}
+	resp.Diagnostics.AddAttributeError(path.Root("site_id"), summary, detail+dependsOn)
+}
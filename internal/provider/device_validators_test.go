@@ -0,0 +1,479 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// validateDeviceConfig runs ValidateResourceConfig for a towerops_device
+// through the provider server, with every attribute not in attrs null. It
+// returns the error diagnostics.
+func validateDeviceConfig(t *testing.T, attrs map[string]tftypes.Value) []*tfprotov6.Diagnostic {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error creating provider server: %v", err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	objectType := schemaResp.ResourceSchemas["towerops_device"].ValueType().(tftypes.Object)
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, typ := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	values["ip_address"] = tftypes.NewValue(tftypes.String, "10.0.0.1")
+	for name, value := range attrs {
+		values[name] = value
+	}
+
+	config, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatalf("unexpected error encoding config: %v", err)
+	}
+
+	resp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: "towerops_device",
+		Config:   &config,
+		ClientCapabilities: &tfprotov6.ValidateResourceConfigClientCapabilities{
+			WriteOnlyAttributesAllowed: true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var errs []*tfprotov6.Diagnostic
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			errs = append(errs, d)
+		}
+	}
+	return errs
+}
+
+func tfString(value string) tftypes.Value {
+	return tftypes.NewValue(tftypes.String, value)
+}
+
+func TestDeviceResource_ValidateConfig_snmp(t *testing.T) {
+	tests := []struct {
+		name    string
+		attrs   map[string]tftypes.Value
+		wantErr string
+	}{
+		{
+			name:  "defaults",
+			attrs: nil,
+		},
+		{
+			name: "v2c",
+			attrs: map[string]tftypes.Value{
+				"snmp_version": tfString("2c"),
+				"snmp_port":    tftypes.NewValue(tftypes.Number, 1161),
+			},
+		},
+		{
+			name:    "unknown version",
+			attrs:   map[string]tftypes.Value{"snmp_version": tfString("2")},
+			wantErr: "snmp_version",
+		},
+		{
+			name:    "port zero",
+			attrs:   map[string]tftypes.Value{"snmp_port": tftypes.NewValue(tftypes.Number, 0)},
+			wantErr: "snmp_port",
+		},
+		{
+			name:    "port too large",
+			attrs:   map[string]tftypes.Value{"snmp_port": tftypes.NewValue(tftypes.Number, 65536)},
+			wantErr: "snmp_port",
+		},
+		{
+			name: "unknown security level",
+			attrs: map[string]tftypes.Value{
+				"snmp_version":          tfString("3"),
+				"snmpv3_security_level": tfString("authpriv"),
+			},
+			wantErr: "snmpv3_security_level",
+		},
+		{
+			name: "unknown auth protocol",
+			attrs: map[string]tftypes.Value{
+				"snmp_version":         tfString("3"),
+				"snmpv3_auth_protocol": tfString("SHA1"),
+			},
+			wantErr: "snmpv3_auth_protocol",
+		},
+		{
+			name: "unknown priv protocol",
+			attrs: map[string]tftypes.Value{
+				"snmp_version":         tfString("3"),
+				"snmpv3_priv_protocol": tfString("3DES"),
+			},
+			wantErr: "snmpv3_priv_protocol",
+		},
+		{
+			name: "v3 fields on v2c",
+			attrs: map[string]tftypes.Value{
+				"snmp_version":    tfString("2c"),
+				"snmpv3_username": tfString("monitor"),
+			},
+			wantErr: "snmpv3_username",
+		},
+		{
+			name: "v3 write-only password on v1",
+			attrs: map[string]tftypes.Value{
+				"snmp_version":                    tfString("1"),
+				"snmpv3_auth_password_wo":         tfString("secret"),
+				"snmpv3_auth_password_wo_version": tftypes.NewValue(tftypes.Number, 1),
+			},
+			wantErr: "snmpv3_auth_password_wo",
+		},
+		{
+			name: "authPriv",
+			attrs: map[string]tftypes.Value{
+				"snmp_version":          tfString("3"),
+				"snmpv3_security_level": tfString("authPriv"),
+				"snmpv3_username":       tfString("monitor"),
+				"snmpv3_auth_protocol":  tfString("SHA-256"),
+				"snmpv3_auth_password":  tfString("auth-secret"),
+				"snmpv3_priv_protocol":  tfString("AES"),
+				"snmpv3_priv_password":  tfString("priv-secret"),
+			},
+		},
+		{
+			name: "noAuthNoPriv with auth password",
+			attrs: map[string]tftypes.Value{
+				"snmp_version":          tfString("3"),
+				"snmpv3_security_level": tfString("noAuthNoPriv"),
+				"snmpv3_auth_password":  tfString("auth-secret"),
+			},
+			wantErr: "snmpv3_auth_password",
+		},
+		{
+			name: "noAuthNoPriv with write-only priv password",
+			attrs: map[string]tftypes.Value{
+				"snmp_version":                    tfString("3"),
+				"snmpv3_security_level":           tfString("noAuthNoPriv"),
+				"snmpv3_priv_password_wo":         tfString("priv-secret"),
+				"snmpv3_priv_password_wo_version": tftypes.NewValue(tftypes.Number, 1),
+			},
+			wantErr: "snmpv3_priv_password_wo",
+		},
+		{
+			name: "authNoPriv with priv protocol",
+			attrs: map[string]tftypes.Value{
+				"snmp_version":          tfString("3"),
+				"snmpv3_security_level": tfString("authNoPriv"),
+				"snmpv3_priv_protocol":  tfString("AES"),
+			},
+			wantErr: "snmpv3_priv_protocol",
+		},
+		{
+			name: "unknown version skips version rule",
+			attrs: map[string]tftypes.Value{
+				"snmp_version":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"snmpv3_username": tfString("monitor"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validateDeviceConfig(t, tt.attrs)
+
+			if tt.wantErr == "" {
+				for _, d := range errs {
+					t.Errorf("unexpected error: %s: %s", d.Summary, d.Detail)
+				}
+				return
+			}
+
+			if len(errs) != 1 {
+				for _, d := range errs {
+					t.Logf("%s: %s: %s", d.Attribute, d.Summary, d.Detail)
+				}
+				t.Fatalf("expected one error for %s, got %d", tt.wantErr, len(errs))
+			}
+			if got := errs[0].Attribute.String(); got != `AttributeName("`+tt.wantErr+`")` {
+				t.Errorf("expected error on %s, got %s: %s", tt.wantErr, got, errs[0].Detail)
+			}
+		})
+	}
+}
+
+func TestDeviceResource_ModifyPlan_snmpv3Requirements(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		defaults DeviceDefaults
+		config   DeviceResourceModel
+		missing  []string
+	}{
+		{
+			name: "authPriv complete",
+			config: DeviceResourceModel{
+				SNMPVersion:         types.StringValue("3"),
+				SNMPv3SecurityLevel: types.StringValue("authPriv"),
+				SNMPv3AuthProtocol:  types.StringValue("SHA-256"),
+				SNMPv3AuthPassword:  types.StringValue("auth-secret"),
+				SNMPv3PrivProtocol:  types.StringValue("AES"),
+				SNMPv3PrivPassword:  types.StringValue("priv-secret"),
+			},
+		},
+		{
+			name: "authPriv without priv settings",
+			config: DeviceResourceModel{
+				SNMPVersion:         types.StringValue("3"),
+				SNMPv3SecurityLevel: types.StringValue("authPriv"),
+				SNMPv3AuthProtocol:  types.StringValue("SHA-256"),
+				SNMPv3AuthPassword:  types.StringValue("auth-secret"),
+			},
+			missing: []string{"snmpv3_priv_protocol", "snmpv3_priv_password"},
+		},
+		{
+			name: "authNoPriv without password",
+			config: DeviceResourceModel{
+				SNMPVersion:         types.StringValue("3"),
+				SNMPv3SecurityLevel: types.StringValue("authNoPriv"),
+				SNMPv3AuthProtocol:  types.StringValue("SHA"),
+			},
+			missing: []string{"snmpv3_auth_password"},
+		},
+		{
+			name: "authPriv completed by device_defaults",
+			defaults: DeviceDefaults{
+				SNMPv3AuthProtocol: strPtr("SHA-256"),
+				SNMPv3AuthPassword: strPtr("auth-secret"),
+				SNMPv3PrivProtocol: strPtr("AES"),
+				SNMPv3PrivPassword: strPtr("priv-secret"),
+			},
+			config: DeviceResourceModel{
+				SNMPVersion:         types.StringValue("3"),
+				SNMPv3SecurityLevel: types.StringValue("authPriv"),
+			},
+		},
+		{
+			name: "v3 fields on the default version",
+			config: DeviceResourceModel{
+				SNMPv3Username: types.StringValue("monitor"),
+			},
+			missing: []string{"snmpv3_username"},
+		},
+		{
+			name:     "v3 fields with version from device_defaults",
+			defaults: DeviceDefaults{SNMPVersion: strPtr("3")},
+			config: DeviceResourceModel{
+				SNMPv3Username: types.StringValue("monitor"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.config.IPAddress = NewIPAddressValue("10.0.0.1")
+
+			r := &DeviceResource{client: &Client{org: &Organization{}, DeviceDefaults: tt.defaults}}
+			req, resp := newDeviceModifyPlanRequest(t, tt.config)
+			r.ModifyPlan(ctx, req, resp)
+
+			if len(tt.missing) == 0 {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected an error naming %v", tt.missing)
+			}
+			for _, name := range tt.missing {
+				found := false
+				for _, d := range resp.Diagnostics.Errors() {
+					if strings.Contains(d.Detail(), name) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("expected an error naming %s, got: %v", name, resp.Diagnostics)
+				}
+			}
+		})
+	}
+}
+
+func TestDeviceResource_ModifyPlan_useSites(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		useSites bool
+		managed  bool
+		planned  *UseSitesPlan
+		siteID   types.String
+		wantErr  string
+		wantWarn string
+	}{
+		{name: "sites with site", useSites: true, siteID: types.StringValue("site-1")},
+		{name: "sites with unknown site", useSites: true, siteID: types.StringUnknown()},
+		{name: "sites without site", useSites: true, siteID: types.StringNull(), wantErr: "Device Requires a Site"},
+		{name: "no sites without site", useSites: false, siteID: types.StringNull()},
+		{name: "no sites with site", useSites: false, siteID: types.StringValue("site-1"), wantErr: "Organization Does Not Use Sites"},
+		{
+			name:     "organization not planned yet",
+			useSites: true,
+			managed:  true,
+			siteID:   types.StringNull(),
+			wantWarn: "Device Requires a Site",
+		},
+		{
+			name:     "plan keeps sites off",
+			useSites: false,
+			planned:  &UseSitesPlan{Known: true, Value: false},
+			siteID:   types.StringValue("site-1"),
+			wantErr:  "Organization Does Not Use Sites",
+		},
+		{
+			name:     "plan turns sites on",
+			useSites: false,
+			planned:  &UseSitesPlan{Known: true, Value: true, Changing: true},
+			siteID:   types.StringNull(),
+			wantErr:  "Device Requires a Site",
+		},
+		{
+			name:     "plan turns sites off",
+			useSites: true,
+			planned:  &UseSitesPlan{Known: true, Value: false, Changing: true},
+			siteID:   types.StringNull(),
+		},
+		{
+			name:     "plan leaves sites on",
+			useSites: true,
+			planned:  &UseSitesPlan{Known: true, Value: true},
+			siteID:   types.StringValue("site-1"),
+		},
+		{
+			name:     "plan change unknown",
+			useSites: true,
+			planned:  &UseSitesPlan{Changing: true},
+			siteID:   types.StringNull(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &Client{org: &Organization{UseSites: tt.useSites}}
+			if tt.managed {
+				client.SetOrganizationManaged()
+			}
+			if tt.planned != nil {
+				client.SetPlannedUseSites(*tt.planned)
+			}
+
+			r := &DeviceResource{client: client}
+			req, resp := newDeviceModifyPlanRequest(t, DeviceResourceModel{
+				IPAddress: NewIPAddressValue("10.0.0.1"),
+				SiteID:    tt.siteID,
+			})
+			r.ModifyPlan(ctx, req, resp)
+
+			var warnings []string
+			for _, d := range resp.Diagnostics.Warnings() {
+				warnings = append(warnings, d.Summary())
+			}
+			if tt.wantWarn == "" && len(warnings) > 0 || tt.wantWarn != "" && (len(warnings) != 1 || warnings[0] != tt.wantWarn) {
+				t.Errorf("expected warning %q, got %v", tt.wantWarn, warnings)
+			}
+
+			if tt.wantErr == "" {
+				if resp.Diagnostics.HasError() {
+					t.Fatalf("unexpected error: %v", resp.Diagnostics)
+				}
+				return
+			}
+
+			if !resp.Diagnostics.HasError() {
+				t.Fatalf("expected error %q", tt.wantErr)
+			}
+			if got := resp.Diagnostics.Errors()[0].Summary(); got != tt.wantErr {
+				t.Errorf("expected error %q, got %q", tt.wantErr, got)
+			}
+			if tt.planned != nil && tt.planned.Changing && !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "in this plan") {
+				t.Errorf("expected the error to mention the planned change, got: %s", resp.Diagnostics.Errors()[0].Detail())
+			}
+		})
+	}
+}
+
+func TestDeviceResource_ModifyPlan_useSitesDevicePlannedFirst(t *testing.T) {
+	ctx := context.Background()
+
+	client := &Client{org: &Organization{UseSites: false}}
+	device := &DeviceResource{client: client}
+	newRequest := func() (fwresource.ModifyPlanRequest, *fwresource.ModifyPlanResponse) {
+		return newDeviceModifyPlanRequest(t, DeviceResourceModel{
+			IPAddress: NewIPAddressValue("10.0.0.1"),
+			SiteID:    types.StringValue("site-1"),
+		})
+	}
+
+	// Without a towerops_organization in the run, the current setting is
+	// final.
+	req, resp := newRequest()
+	device.ModifyPlan(ctx, req, resp)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "depends_on") {
+		t.Errorf("expected an error pointing at depends_on, got: %v", resp.Diagnostics)
+	}
+
+	// Once the organization has been validated, Terraform may still plan the
+	// device before it turns sites on, so only a warning is possible.
+	org := &OrganizationResource{client: client}
+	schemaResp := &fwresource.SchemaResponse{}
+	org.Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+	orgConfig := tfsdk.Config{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	org.ValidateConfig(ctx, fwresource.ValidateConfigRequest{Config: orgConfig}, &fwresource.ValidateConfigResponse{})
+
+	req, resp = newRequest()
+	device.ModifyPlan(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || !strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), "depends_on") {
+		t.Errorf("expected a warning pointing at depends_on, got: %v", resp.Diagnostics)
+	}
+
+	// Once the organization has been planned, the device fits its plan.
+	orgPlan := tfsdk.Plan{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	if diags := orgPlan.SetAttribute(ctx, path.Root("use_sites"), types.BoolValue(true)); diags.HasError() {
+		t.Fatalf("unexpected error building plan: %v", diags)
+	}
+	orgResp := &fwresource.ModifyPlanResponse{Plan: orgPlan}
+	org.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+		Plan:  orgPlan,
+		State: tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(orgPlan.Raw.Type(), nil)},
+	}, orgResp)
+	if orgResp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", orgResp.Diagnostics)
+	}
+
+	req, resp = newRequest()
+	device.ModifyPlan(ctx, req, resp)
+	if len(resp.Diagnostics) != 0 {
+		t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+}
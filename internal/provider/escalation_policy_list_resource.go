@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &EscalationPolicyListResource{}
+var _ list.ListResourceWithConfigure = &EscalationPolicyListResource{}
+
+// EscalationPolicyListResource lists existing escalation policies for terraform query.
+type EscalationPolicyListResource struct {
+	client *Client
+}
+
+// EscalationPolicyListResourceModel describes the list filters.
+type EscalationPolicyListResourceModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+}
+
+// NewEscalationPolicyListResource creates a new escalation policy list resource.
+func NewEscalationPolicyListResource() list.ListResource {
+	return &EscalationPolicyListResource{}
+}
+
+func (r *EscalationPolicyListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_escalation_policy"
+}
+
+func (r *EscalationPolicyListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing TowerOps escalation policies.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Description: "Only list escalation policies whose name starts with this prefix.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *EscalationPolicyListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *EscalationPolicyListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config EscalationPolicyListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	policies, err := r.client.ListEscalationPolicies()
+	if err != nil {
+		stream.Results = listErrorStream("Failed to list escalation policies", err.Error())
+		return
+	}
+
+	var matched []EscalationPolicyAPI
+	for _, policy := range policies {
+		if !strings.HasPrefix(policy.Name, config.NamePrefix.ValueString()) {
+			continue
+		}
+		matched = append(matched, policy)
+	}
+
+	stream.Results = streamListResults(ctx, r.client, req, matched,
+		func(policy EscalationPolicyAPI) string { return policy.ID },
+		func(policy EscalationPolicyAPI, result *list.ListResult) {
+			result.DisplayName = policy.Name
+			if !req.IncludeResource {
+				return
+			}
+
+			data := EscalationPolicyResourceModel{
+				ID:         types.StringValue(policy.ID),
+				Name:       types.StringValue(policy.Name),
+				InsertedAt: NewTimestampValue(policy.InsertedAt),
+			}
+			if policy.Description != nil {
+				data.Description = types.StringValue(*policy.Description)
+			}
+			if policy.RepeatCount != nil {
+				data.RepeatCount = types.Int64Value(int64(*policy.RepeatCount))
+			}
+
+			var d diag.Diagnostics
+			data.Labels, data.LabelsAll, d = labelsForImport(ctx, r.client, policy.Labels)
+			result.Diagnostics.Append(d...)
+
+			result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+		},
+	)
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
@@ -15,7 +14,10 @@ import (
 )
 
 var _ resource.Resource = &EscalationPolicyResource{}
+var _ resource.ResourceWithUpgradeState = &EscalationPolicyResource{}
 var _ resource.ResourceWithImportState = &EscalationPolicyResource{}
+var _ resource.ResourceWithIdentity = &EscalationPolicyResource{}
+var _ resource.ResourceWithModifyPlan = &EscalationPolicyResource{}
 
 // EscalationPolicyResource defines the resource implementation.
 type EscalationPolicyResource struct {
@@ -24,11 +26,13 @@ type EscalationPolicyResource struct {
 
 // EscalationPolicyResourceModel describes the resource data model.
 type EscalationPolicyResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	RepeatCount types.Int64  `tfsdk:"repeat_count"`
-	InsertedAt  types.String `tfsdk:"inserted_at"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	RepeatCount types.Int64    `tfsdk:"repeat_count"`
+	Labels      types.Map      `tfsdk:"labels"`
+	LabelsAll   types.Map      `tfsdk:"labels_all"`
+	InsertedAt  TimestampValue `tfsdk:"inserted_at"`
 }
 
 // NewEscalationPolicyResource creates a new escalation policy resource.
@@ -40,8 +44,13 @@ func (r *EscalationPolicyResource) Metadata(ctx context.Context, req resource.Me
 	resp.TypeName = req.ProviderTypeName + "_escalation_policy"
 }
 
+func (r *EscalationPolicyResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema()
+}
+
 func (r *EscalationPolicyResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages a TowerOps escalation policy.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -67,7 +76,10 @@ func (r *EscalationPolicyResource) Schema(ctx context.Context, req resource.Sche
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
+			"labels":     labelsAttribute(),
+			"labels_all": labelsAllAttribute(),
 			"inserted_at": schema.StringAttribute{
+				CustomType:  TimestampType{},
 				Description: "The timestamp when the escalation policy was created.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
@@ -78,6 +90,12 @@ func (r *EscalationPolicyResource) Schema(ctx context.Context, req resource.Sche
 	}
 }
 
+func (r *EscalationPolicyResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r,
+		upgradeFromV0,
+	)
+}
+
 func (r *EscalationPolicyResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -95,6 +113,10 @@ func (r *EscalationPolicyResource) Configure(ctx context.Context, req resource.C
 	r.client = client
 }
 
+func (r *EscalationPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanLabels(ctx, r.client, req, resp)
+}
+
 func (r *EscalationPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data EscalationPolicyResourceModel
 
@@ -117,6 +139,13 @@ func (r *EscalationPolicyResource) Create(ctx context.Context, req resource.Crea
 		policy.RepeatCount = &rc
 	}
 
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	policy.Labels = labels
+
 	created, err := r.client.CreateEscalationPolicy(policy)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create escalation policy", err.Error())
@@ -124,7 +153,8 @@ func (r *EscalationPolicyResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	data.ID = types.StringValue(created.ID)
-	data.InsertedAt = types.StringValue(created.InsertedAt)
+	data.InsertedAt = NewTimestampValue(created.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, created.Labels)...)
 
 	if created.Description != nil {
 		data.Description = types.StringValue(*created.Description)
@@ -134,6 +164,7 @@ func (r *EscalationPolicyResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *EscalationPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -155,7 +186,8 @@ func (r *EscalationPolicyResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	data.Name = types.StringValue(policy.Name)
-	data.InsertedAt = types.StringValue(policy.InsertedAt)
+	data.InsertedAt = NewTimestampValue(policy.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, policy.Labels)...)
 
 	if policy.Description != nil {
 		data.Description = types.StringValue(*policy.Description)
@@ -170,6 +202,7 @@ func (r *EscalationPolicyResource) Read(ctx context.Context, req resource.ReadRe
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *EscalationPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -194,24 +227,17 @@ func (r *EscalationPolicyResource) Update(ctx context.Context, req resource.Upda
 		policy.RepeatCount = &rc
 	}
 
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	policy.Labels = labels
+
 	updated, err := r.client.UpdateEscalationPolicy(data.ID.ValueString(), policy)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			created, createErr := r.client.CreateEscalationPolicy(policy)
-			if createErr != nil {
-				resp.Diagnostics.AddError("Failed to create escalation policy (after 404 on update)", createErr.Error())
-				return
-			}
-			data.ID = types.StringValue(created.ID)
-			data.InsertedAt = types.StringValue(created.InsertedAt)
-			data.Name = types.StringValue(created.Name)
-			if created.Description != nil {
-				data.Description = types.StringValue(*created.Description)
-			}
-			if created.RepeatCount != nil {
-				data.RepeatCount = types.Int64Value(int64(*created.RepeatCount))
-			}
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.Append(deletedDuringUpdate("escalation policy", data.ID.ValueString()))
 			return
 		}
 		resp.Diagnostics.AddError("Failed to update escalation policy", err.Error())
@@ -227,7 +253,10 @@ func (r *EscalationPolicyResource) Update(ctx context.Context, req resource.Upda
 		data.RepeatCount = types.Int64Value(int64(*updated.RepeatCount))
 	}
 
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, updated.Labels)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *EscalationPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -246,5 +275,14 @@ func (r *EscalationPolicyResource) Delete(ctx context.Context, req resource.Dele
 }
 
 func (r *EscalationPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if key, ok := parseImportKey(req.ID); ok {
+		id, err := resolveEscalationPolicyImportKey(r.client, key)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot Import Escalation Policy", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	importStateWithIdentity(ctx, r.client, req, resp)
 }
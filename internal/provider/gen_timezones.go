@@ -0,0 +1,125 @@
+//go:build ignore
+
+// gen_timezones writes timezone_names.go from the zone database that ships
+// with the Go toolchain, which is the one time/tzdata embeds. Every zone in
+// lib/time/zoneinfo.zip is listed except the deprecated aliases in
+// timezone_aliases.go and the placeholder Factory zone.
+//
+// Run it with go generate after upgrading Go.
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	goroot, err := exec.Command("go", "env", "GOROOT").Output()
+	if err != nil {
+		log.Fatalf("finding GOROOT: %v", err)
+	}
+	libTime := filepath.Join(strings.TrimSpace(string(goroot)), "lib", "time")
+
+	release, err := tzRelease(filepath.Join(libTime, "update.bash"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	aliases, err := aliasNames("timezone_aliases.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	archive, err := zip.OpenReader(filepath.Join(libTime, "zoneinfo.zip"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer archive.Close()
+
+	var names []string
+	for _, file := range archive.File {
+		if file.Name == "Factory" || aliases[file.Name] {
+			continue
+		}
+		names = append(names, file.Name)
+	}
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen_timezones.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package provider\n\n")
+	fmt.Fprintf(&buf, "// Time zone names from release %s of the IANA time zone database, as\n", release)
+	fmt.Fprintf(&buf, "// shipped with Go. Go builds it with backzone, so that every zone in\n")
+	fmt.Fprintf(&buf, "// zone.tab is listed under its own name.\n\n")
+	fmt.Fprintf(&buf, "// timezoneNames lists the canonical zone names.\n")
+	fmt.Fprintf(&buf, "var timezoneNames = []string{\n")
+	for _, name := range names {
+		fmt.Fprintf(&buf, "\t%q,\n", name)
+	}
+	fmt.Fprintf(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("timezone_names.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// tzRelease returns the tz database release that update.bash built the zip
+// from.
+func tzRelease(updateScript string) (string, error) {
+	script, err := os.ReadFile(updateScript)
+	if err != nil {
+		return "", err
+	}
+
+	match := regexp.MustCompile(`(?m)^DATA=(\S+)$`).FindSubmatch(script)
+	if match == nil {
+		return "", fmt.Errorf("no DATA= line in %s", updateScript)
+	}
+	return string(match[1]), nil
+}
+
+// aliasNames returns the keys of the timezoneAliases map in file.
+func aliasNames(file string) (map[string]bool, error) {
+	parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool)
+	ast.Inspect(parsed, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || len(spec.Names) != 1 || spec.Names[0].Name != "timezoneAliases" {
+			return true
+		}
+		for _, elt := range spec.Values[0].(*ast.CompositeLit).Elts {
+			key, err := strconv.Unquote(elt.(*ast.KeyValueExpr).Key.(*ast.BasicLit).Value)
+			if err != nil {
+				return false
+			}
+			names[key] = true
+		}
+		return false
+	})
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no timezoneAliases in %s", file)
+	}
+	return names, nil
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// resourceIdentityModel describes the identity shared by TowerOps resources.
+type resourceIdentityModel struct {
+	ID             types.String `tfsdk:"id"`
+	OrganizationID types.String `tfsdk:"organization_id"`
+}
+
+// resourceIdentitySchema returns the identity schema shared by TowerOps
+// resources.
+func resourceIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"id": identityschema.StringAttribute{
+				Description:       "The unique identifier of the object.",
+				RequiredForImport: true,
+			},
+			"organization_id": identityschema.StringAttribute{
+				Description:       "The ID of the organization that owns the object, or null if the provider's token cannot read the organization. When set on import, it must match the organization of the provider's token.",
+				OptionalForImport: true,
+			},
+		},
+	}
+}
+
+// setResourceIdentity records the identity for the object with the given ID.
+// An identity already recorded for the object is kept as it is, since
+// identities cannot change. Otherwise the organization is taken from the
+// client. Tokens that may not read the organization, such as scoped API
+// tokens, get an identity without one, so they can still manage resources.
+func setResourceIdentity(ctx context.Context, client *Client, identity *tfsdk.ResourceIdentity, id string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if identity == nil {
+		return diags
+	}
+
+	if !identity.Raw.IsNull() {
+		var prior resourceIdentityModel
+		diags.Append(identity.Get(ctx, &prior)...)
+		if diags.HasError() || prior.ID.ValueString() == id {
+			return diags
+		}
+	}
+
+	data := resourceIdentityModel{
+		ID:             types.StringValue(id),
+		OrganizationID: types.StringNull(),
+	}
+
+	if client != nil {
+		orgID, err := client.OrganizationID()
+		switch {
+		case err == nil:
+			data.OrganizationID = types.StringValue(orgID)
+		case errors.Is(err, ErrForbidden):
+			tflog.Debug(ctx, "Recording identity without organization", map[string]any{
+				"error": err.Error(),
+			})
+		default:
+			diags.AddError(
+				"Unable to Determine Organization",
+				"The resource identity needs the ID of the organization that owns the object.\n\n"+err.Error(),
+			)
+			return diags
+		}
+	}
+
+	diags.Append(identity.Set(ctx, data)...)
+	return diags
+}
+
+// deletedDuringUpdate reports an object that Update found deleted outside of
+// Terraform. It is not recreated in place, since the new object would have a
+// new ID and resource identities cannot change. Refreshing removes it from
+// state, so the next plan creates it again.
+func deletedDuringUpdate(kind, id string) diag.Diagnostic {
+	return diag.NewErrorDiagnostic(
+		fmt.Sprintf("Failed to update %s", kind),
+		fmt.Sprintf("The %s %s no longer exists; it was deleted outside of Terraform. Run terraform apply again with refresh enabled to create a new %s.", kind, id, kind),
+	)
+}
+
+// importStateWithIdentity imports a resource by UUID or by identity. An
+// identity that names a different organization than the provider's token is
+// rejected, since the object could not be read with that token anyway.
+func importStateWithIdentity(ctx context.Context, client *Client, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, path.Root("id"), path.Root("id"), req, resp)
+	if resp.Diagnostics.HasError() || req.Identity == nil || req.Identity.Raw.IsNull() {
+		return
+	}
+
+	var identity resourceIdentityModel
+	resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+	if resp.Diagnostics.HasError() || identity.OrganizationID.IsNull() {
+		return
+	}
+
+	orgID, err := client.OrganizationID()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to Verify Organization", err.Error())
+		return
+	}
+
+	if identity.OrganizationID.ValueString() != orgID {
+		resp.Diagnostics.AddError(
+			"Organization Mismatch",
+			fmt.Sprintf("The identity belongs to organization %q, but the provider's token belongs to organization %q.", identity.OrganizationID.ValueString(), orgID),
+		)
+	}
+}
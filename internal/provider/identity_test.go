@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestImportStateWithIdentity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"id": "org-123", "name": "Test ISP"}}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		organizationID types.String
+		wantError      bool
+	}{
+		{name: "matching organization", organizationID: types.StringValue("org-123")},
+		{name: "no organization", organizationID: types.StringNull()},
+		{name: "other organization", organizationID: types.StringValue("org-456"), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			schemaResp := &fwresource.SchemaResponse{}
+			NewSiteResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+			identitySchema := resourceIdentitySchema()
+
+			identity := tfsdk.ResourceIdentity{Schema: identitySchema}
+			if diags := identity.Set(ctx, resourceIdentityModel{
+				ID:             types.StringValue("site-123"),
+				OrganizationID: tt.organizationID,
+			}); diags.HasError() {
+				t.Fatalf("unexpected error building identity: %v", diags)
+			}
+
+			req := fwresource.ImportStateRequest{Identity: &identity}
+			resp := &fwresource.ImportStateResponse{
+				State: tfsdk.State{
+					Schema: schemaResp.Schema,
+					Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+				},
+				Identity: &tfsdk.ResourceIdentity{Schema: identitySchema, Raw: identity.Raw.Copy()},
+			}
+
+			importStateWithIdentity(ctx, NewClient("test-token", server.URL), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("expected error %v, got diagnostics: %v", tt.wantError, resp.Diagnostics)
+			}
+			if tt.wantError {
+				return
+			}
+
+			var id types.String
+			resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("id"), &id)...)
+			if id.ValueString() != "site-123" {
+				t.Errorf("expected id site-123 in state, got %s", id)
+			}
+		})
+	}
+}
+
+func TestImportResourceState_identity(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/organization" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"data": {"id": "org-123", "name": "Test ISP"}}`))
+	}))
+	defer server.Close()
+
+	provider, schemaResp := newConfiguredProviderServer(t, server.URL, nil)
+	identityResp, err := provider.GetResourceIdentitySchemas(ctx, &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resourceTypes := []string{
+		"towerops_agent",
+		"towerops_device",
+		"towerops_escalation_policy",
+		"towerops_integration",
+		"towerops_maintenance_window",
+		"towerops_schedule",
+		"towerops_site",
+	}
+
+	tests := []struct {
+		name           string
+		organizationID any
+		wantError      string
+	}{
+		{name: "matching organization", organizationID: "org-123"},
+		{name: "no organization"},
+		{name: "other organization", organizationID: "org-456", wantError: "Organization Mismatch"},
+	}
+
+	for _, typeName := range resourceTypes {
+		for _, tt := range tests {
+			t.Run(typeName+"/"+tt.name, func(t *testing.T) {
+				identitySchema, ok := identityResp.IdentitySchemas[typeName]
+				if !ok {
+					t.Fatalf("expected identity schema for %s", typeName)
+				}
+				identityType := identitySchema.ValueType().(tftypes.Object)
+
+				resp, err := provider.ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+					TypeName: typeName,
+					Identity: &tfprotov6.ResourceIdentityData{
+						IdentityData: newDynamicValue(t, identityType, map[string]tftypes.Value{
+							"id":              tfString("object-123"),
+							"organization_id": tftypes.NewValue(tftypes.String, tt.organizationID),
+						}),
+					},
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				var errs []string
+				for _, d := range resp.Diagnostics {
+					if d.Severity == tfprotov6.DiagnosticSeverityError {
+						errs = append(errs, d.Summary)
+					}
+				}
+				if tt.wantError != "" {
+					if len(errs) != 1 || errs[0] != tt.wantError {
+						t.Errorf("expected error %q, got: %v", tt.wantError, errs)
+					}
+					return
+				}
+				if len(errs) > 0 {
+					t.Fatalf("unexpected errors: %v", errs)
+				}
+
+				if len(resp.ImportedResources) != 1 {
+					t.Fatalf("expected one imported resource, got %d", len(resp.ImportedResources))
+				}
+				state, err := resp.ImportedResources[0].State.Unmarshal(schemaResp.ResourceSchemas[typeName].ValueType())
+				if err != nil {
+					t.Fatalf("unexpected error decoding state: %v", err)
+				}
+				var values map[string]tftypes.Value
+				if err := state.As(&values); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !values["id"].Equal(tfString("object-123")) {
+					t.Errorf("expected id object-123 in state, got %s", values["id"])
+				}
+			})
+		}
+	}
+}
+
+func TestSetResourceIdentity_organizationForbidden(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"error": "missing scope organization:read"}`))
+	}))
+	defer server.Close()
+
+	identitySchema := resourceIdentitySchema()
+	identity := tfsdk.ResourceIdentity{
+		Schema: identitySchema,
+		Raw:    tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil),
+	}
+
+	diags := setResourceIdentity(ctx, NewClient("test-token", server.URL), &identity, "site-123")
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var got resourceIdentityModel
+	if diags := identity.Get(ctx, &got); diags.HasError() {
+		t.Fatalf("unexpected error reading identity: %v", diags)
+	}
+	if got.ID.ValueString() != "site-123" || !got.OrganizationID.IsNull() {
+		t.Errorf("expected identity for site-123 without organization, got %s", identity.Raw)
+	}
+}
+
+func TestSetResourceIdentity_organizationUnavailable(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error": "unavailable"}`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		prior     *resourceIdentityModel
+		wantError bool
+	}{
+		{name: "no prior identity", wantError: true},
+		{
+			name:  "prior identity for the object",
+			prior: &resourceIdentityModel{ID: types.StringValue("site-123"), OrganizationID: types.StringValue("org-123")},
+		},
+		{
+			name:  "prior identity for the object without organization",
+			prior: &resourceIdentityModel{ID: types.StringValue("site-123"), OrganizationID: types.StringNull()},
+		},
+		{
+			name:      "prior identity for another object",
+			prior:     &resourceIdentityModel{ID: types.StringValue("site-456"), OrganizationID: types.StringValue("org-123")},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identitySchema := resourceIdentitySchema()
+			identity := tfsdk.ResourceIdentity{
+				Schema: identitySchema,
+				Raw:    tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil),
+			}
+			if tt.prior != nil {
+				if diags := identity.Set(ctx, tt.prior); diags.HasError() {
+					t.Fatalf("unexpected error building identity: %v", diags)
+				}
+			}
+			before := identity.Raw.Copy()
+
+			diags := setResourceIdentity(ctx, NewClient("test-token", server.URL), &identity, "site-123")
+
+			if diags.HasError() != tt.wantError {
+				t.Fatalf("expected error %v, got diagnostics: %v", tt.wantError, diags)
+			}
+			if !identity.Raw.Equal(before) {
+				t.Errorf("expected identity to be left as it was, got %s", identity.Raw)
+			}
+		})
+	}
+}
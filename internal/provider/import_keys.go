@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// importKey is an import ID given as a natural key instead of a UUID, such
+// as "name:Main Office", "ip:10.20.1.1" or "site:Main Office/ip:10.20.1.1".
+type importKey struct {
+	// Site optionally scopes the lookup to the site with this name.
+	Site string
+	// Field is the attribute to match: "name" or "ip".
+	Field string
+	// Value is the value the attribute must equal.
+	Value string
+}
+
+func (k importKey) String() string {
+	if k.Site != "" {
+		return fmt.Sprintf("site:%s/%s:%s", k.Site, k.Field, k.Value)
+	}
+	return fmt.Sprintf("%s:%s", k.Field, k.Value)
+}
+
+// parseImportKey parses a natural key import ID. It returns false when the ID
+// is not a natural key and should be treated as a UUID.
+func parseImportKey(id string) (importKey, bool) {
+	var key importKey
+
+	rest := id
+	if site, ok := strings.CutPrefix(id, "site:"); ok {
+		// Site names may contain "/", so split on the last field separator.
+		i := max(strings.LastIndex(site, "/name:"), strings.LastIndex(site, "/ip:"))
+		if i < 0 {
+			// A bare "site:" key has no field to match on.
+			return importKey{Field: "site", Value: site}, true
+		}
+		key.Site = site[:i]
+		rest = site[i+1:]
+	}
+
+	field, value, ok := strings.Cut(rest, ":")
+	if !ok || (field != "name" && field != "ip") {
+		return importKey{}, false
+	}
+
+	key.Field = field
+	key.Value = value
+	return key, true
+}
+
+// checkImportKey returns an error if the key uses a site scope or field that
+// the resource type does not support.
+func checkImportKey(key importKey, resourceName string, allowSite bool, fields ...string) error {
+	if key.Site != "" && !allowSite {
+		return fmt.Errorf("%s import IDs cannot be scoped by site", resourceName)
+	}
+
+	for _, f := range fields {
+		if key.Field == f {
+			return nil
+		}
+	}
+
+	forms := make([]string, len(fields))
+	for i, f := range fields {
+		forms[i] = f + ":<" + f + ">"
+	}
+	msg := fmt.Sprintf("%s import IDs must be a UUID or one of: %s", resourceName, strings.Join(forms, ", "))
+	if allowSite {
+		msg += ", optionally prefixed with site:<site name>/"
+	}
+	return errors.New(msg)
+}
+
+// matchImportKey returns the single ID in matches, or an error naming the
+// key when there is no match or more than one.
+func matchImportKey(key importKey, resourceName string, matches []string) (string, error) {
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no %s matches %q", resourceName, key)
+	case 1:
+		return matches[0], nil
+	default:
+		return "", fmt.Errorf("%q matches more than one %s (%s). Import by UUID instead", key, resourceName, strings.Join(matches, ", "))
+	}
+}
+
+// resolveSiteImportKey returns the ID of the site matching key.
+func resolveSiteImportKey(client *Client, key importKey) (string, error) {
+	if err := checkImportKey(key, "site", false, "name"); err != nil {
+		return "", err
+	}
+
+	return resolveSiteName(client, key, key.Value)
+}
+
+// resolveSiteName returns the ID of the site with the given name.
+func resolveSiteName(client *Client, key importKey, name string) (string, error) {
+	sites, err := client.ListSites()
+	if err != nil {
+		return "", fmt.Errorf("failed to list sites: %w", err)
+	}
+
+	var matches []string
+	for _, site := range sites {
+		if site.Name == name {
+			matches = append(matches, site.ID)
+		}
+	}
+
+	return matchImportKey(key, "site", matches)
+}
+
+// resolveDeviceImportKey returns the ID of the device matching key.
+func resolveDeviceImportKey(client *Client, key importKey) (string, error) {
+	if err := checkImportKey(key, "device", true, "name", "ip"); err != nil {
+		return "", err
+	}
+
+	siteID := ""
+	if key.Site != "" {
+		id, err := resolveSiteName(client, key, key.Site)
+		if err != nil {
+			return "", err
+		}
+		siteID = id
+	}
+
+	devices, err := client.ListDevices(siteID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	var matches []string
+	for _, device := range devices {
+		switch key.Field {
+		case "ip":
+			if sameIPAddress(device.IPAddress, key.Value) {
+				matches = append(matches, device.ID)
+			}
+		case "name":
+			if device.Name != nil && *device.Name == key.Value {
+				matches = append(matches, device.ID)
+			}
+		}
+	}
+
+	return matchImportKey(key, "device", matches)
+}
+
+// resolveScheduleImportKey returns the ID of the on-call schedule matching key.
+func resolveScheduleImportKey(client *Client, key importKey) (string, error) {
+	if err := checkImportKey(key, "schedule", false, "name"); err != nil {
+		return "", err
+	}
+
+	schedules, err := client.ListSchedules()
+	if err != nil {
+		return "", fmt.Errorf("failed to list schedules: %w", err)
+	}
+
+	var matches []string
+	for _, schedule := range schedules {
+		if schedule.Name == key.Value {
+			matches = append(matches, schedule.ID)
+		}
+	}
+
+	return matchImportKey(key, "schedule", matches)
+}
+
+// resolveEscalationPolicyImportKey returns the ID of the escalation policy
+// matching key.
+func resolveEscalationPolicyImportKey(client *Client, key importKey) (string, error) {
+	if err := checkImportKey(key, "escalation policy", false, "name"); err != nil {
+		return "", err
+	}
+
+	policies, err := client.ListEscalationPolicies()
+	if err != nil {
+		return "", fmt.Errorf("failed to list escalation policies: %w", err)
+	}
+
+	var matches []string
+	for _, policy := range policies {
+		if policy.Name == key.Value {
+			matches = append(matches, policy.ID)
+		}
+	}
+
+	return matchImportKey(key, "escalation policy", matches)
+}
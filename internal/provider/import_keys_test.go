@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseImportKey(t *testing.T) {
+	tests := []struct {
+		id     string
+		want   importKey
+		wantOK bool
+	}{
+		{id: "550e8400-e29b-41d4-a716-446655440000", wantOK: false},
+		{id: "name:Main Office", want: importKey{Field: "name", Value: "Main Office"}, wantOK: true},
+		{id: "ip:10.20.1.1", want: importKey{Field: "ip", Value: "10.20.1.1"}, wantOK: true},
+		{id: "site:Main Office/ip:10.20.1.1", want: importKey{Site: "Main Office", Field: "ip", Value: "10.20.1.1"}, wantOK: true},
+		{id: "site:North/South/name:AP 1", want: importKey{Site: "North/South", Field: "name", Value: "AP 1"}, wantOK: true},
+		{id: "site:Main Office", want: importKey{Field: "site", Value: "Main Office"}, wantOK: true},
+		{id: "name:Tower: East", want: importKey{Field: "name", Value: "Tower: East"}, wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, ok := parseImportKey(tt.id)
+			if ok != tt.wantOK {
+				t.Fatalf("expected ok %v, got %v", tt.wantOK, ok)
+			}
+			if got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveDeviceImportKey(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/sites":
+			w.Write([]byte(`{"data": [
+				{"id": "site-1", "name": "Main Office"},
+				{"id": "site-2", "name": "Tower B"},
+				{"id": "site-3", "name": "Tower B"}
+			]}`))
+		case "/api/v1/devices":
+			if r.URL.Query().Get("site_id") == "site-1" {
+				w.Write([]byte(`{"data": [
+					{"id": "dev-1", "site_id": "site-1", "name": "Router", "ip_address": "10.20.1.1"}
+				]}`))
+				return
+			}
+			w.Write([]byte(`{"data": [
+				{"id": "dev-1", "site_id": "site-1", "name": "Router", "ip_address": "10.20.1.1"},
+				{"id": "dev-2", "site_id": "site-2", "name": "Router", "ip_address": "10.30.1.1"}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("test-token", server.URL)
+
+	tests := []struct {
+		id        string
+		want      string
+		wantError string
+	}{
+		{id: "ip:10.30.1.1", want: "dev-2"},
+		{id: "site:Main Office/name:Router", want: "dev-1"},
+		{id: "name:Router", wantError: `"name:Router" matches more than one device (dev-1, dev-2)`},
+		{id: "ip:192.0.2.1", wantError: `no device matches "ip:192.0.2.1"`},
+		{id: "site:Tower B/ip:10.30.1.1", wantError: `matches more than one site (site-2, site-3)`},
+		{id: "site:Main Office", wantError: "device import IDs must be a UUID or one of: name:<name>, ip:<ip>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			key, ok := parseImportKey(tt.id)
+			if !ok {
+				t.Fatalf("expected %q to parse as an import key", tt.id)
+			}
+
+			got, err := resolveDeviceImportKey(client, key)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantError, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestResolveSiteImportKey_rejectsSiteScope(t *testing.T) {
+	_, err := resolveSiteImportKey(nil, importKey{Site: "Main Office", Field: "name", Value: "Other"})
+	if err == nil || err.Error() != "site import IDs cannot be scoped by site" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
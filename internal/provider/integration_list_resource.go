@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &IntegrationListResource{}
+var _ list.ListResourceWithConfigure = &IntegrationListResource{}
+
+// IntegrationListResource lists existing integrations for terraform query.
+type IntegrationListResource struct {
+	client *Client
+}
+
+// IntegrationListResourceModel describes the list filters.
+type IntegrationListResourceModel struct {
+	ProviderType types.String `tfsdk:"provider_type"`
+}
+
+// NewIntegrationListResource creates a new integration list resource.
+func NewIntegrationListResource() list.ListResource {
+	return &IntegrationListResource{}
+}
+
+func (r *IntegrationListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_integration"
+}
+
+func (r *IntegrationListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing TowerOps integrations.",
+		Attributes: map[string]schema.Attribute{
+			"provider_type": schema.StringAttribute{
+				Description: "Only list integrations of this provider type (e.g. pagerduty, slack, webhook).",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *IntegrationListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *IntegrationListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config IntegrationListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	integrations, err := r.client.ListIntegrations()
+	if err != nil {
+		stream.Results = listErrorStream("Failed to list integrations", err.Error())
+		return
+	}
+
+	var matched []Integration
+	for _, integration := range integrations {
+		if !config.ProviderType.IsNull() && integration.Provider != config.ProviderType.ValueString() {
+			continue
+		}
+		matched = append(matched, integration)
+	}
+
+	stream.Results = streamListResults(ctx, r.client, req, matched,
+		func(integration Integration) string { return integration.ID },
+		func(integration Integration, result *list.ListResult) {
+			result.DisplayName = integration.Provider
+			if !req.IncludeResource {
+				return
+			}
+
+			data := IntegrationResourceModel{
+				ID:           types.StringValue(integration.ID),
+				ProviderType: types.StringValue(integration.Provider),
+				InsertedAt:   NewTimestampValue(integration.InsertedAt),
+			}
+			if integration.Enabled != nil {
+				data.Enabled = types.BoolValue(*integration.Enabled)
+			}
+			if integration.SyncIntervalMinutes != nil {
+				data.SyncIntervalMinutes = types.Int64Value(int64(*integration.SyncIntervalMinutes))
+			}
+
+			var d diag.Diagnostics
+			data.Labels, data.LabelsAll, d = labelsForImport(ctx, r.client, integration.Labels)
+			result.Diagnostics.Append(d...)
+
+			result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+		},
+	)
+}
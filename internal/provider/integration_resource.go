@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -15,7 +14,10 @@ import (
 )
 
 var _ resource.Resource = &IntegrationResource{}
+var _ resource.ResourceWithUpgradeState = &IntegrationResource{}
 var _ resource.ResourceWithImportState = &IntegrationResource{}
+var _ resource.ResourceWithIdentity = &IntegrationResource{}
+var _ resource.ResourceWithModifyPlan = &IntegrationResource{}
 
 // IntegrationResource defines the resource implementation.
 type IntegrationResource struct {
@@ -24,11 +26,13 @@ type IntegrationResource struct {
 
 // IntegrationResourceModel describes the resource data model.
 type IntegrationResourceModel struct {
-	ID                  types.String `tfsdk:"id"`
-	ProviderType        types.String `tfsdk:"provider_type"`
-	Enabled             types.Bool   `tfsdk:"enabled"`
-	SyncIntervalMinutes types.Int64  `tfsdk:"sync_interval_minutes"`
-	InsertedAt          types.String `tfsdk:"inserted_at"`
+	ID                  types.String   `tfsdk:"id"`
+	ProviderType        types.String   `tfsdk:"provider_type"`
+	Enabled             types.Bool     `tfsdk:"enabled"`
+	SyncIntervalMinutes types.Int64    `tfsdk:"sync_interval_minutes"`
+	Labels              types.Map      `tfsdk:"labels"`
+	LabelsAll           types.Map      `tfsdk:"labels_all"`
+	InsertedAt          TimestampValue `tfsdk:"inserted_at"`
 }
 
 // NewIntegrationResource creates a new integration resource.
@@ -40,8 +44,13 @@ func (r *IntegrationResource) Metadata(ctx context.Context, req resource.Metadat
 	resp.TypeName = req.ProviderTypeName + "_integration"
 }
 
+func (r *IntegrationResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema()
+}
+
 func (r *IntegrationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages a TowerOps integration with third-party services.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -65,7 +74,10 @@ func (r *IntegrationResource) Schema(ctx context.Context, req resource.SchemaReq
 				Description: "How often the integration syncs, in minutes.",
 				Optional:    true,
 			},
+			"labels":     labelsAttribute(),
+			"labels_all": labelsAllAttribute(),
 			"inserted_at": schema.StringAttribute{
+				CustomType:  TimestampType{},
 				Description: "The timestamp when the integration was created.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
@@ -76,6 +88,12 @@ func (r *IntegrationResource) Schema(ctx context.Context, req resource.SchemaReq
 	}
 }
 
+func (r *IntegrationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r,
+		upgradeFromV0,
+	)
+}
+
 func (r *IntegrationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -93,6 +111,10 @@ func (r *IntegrationResource) Configure(ctx context.Context, req resource.Config
 	r.client = client
 }
 
+func (r *IntegrationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanLabels(ctx, r.client, req, resp)
+}
+
 func (r *IntegrationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data IntegrationResourceModel
 
@@ -115,6 +137,13 @@ func (r *IntegrationResource) Create(ctx context.Context, req resource.CreateReq
 		integration.SyncIntervalMinutes = &interval
 	}
 
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	integration.Labels = labels
+
 	created, err := r.client.CreateIntegration(integration)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create integration", err.Error())
@@ -122,7 +151,8 @@ func (r *IntegrationResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	data.ID = types.StringValue(created.ID)
-	data.InsertedAt = types.StringValue(created.InsertedAt)
+	data.InsertedAt = NewTimestampValue(created.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, created.Labels)...)
 
 	if created.Enabled != nil {
 		data.Enabled = types.BoolValue(*created.Enabled)
@@ -132,6 +162,7 @@ func (r *IntegrationResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *IntegrationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -153,7 +184,8 @@ func (r *IntegrationResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	data.ProviderType = types.StringValue(integration.Provider)
-	data.InsertedAt = types.StringValue(integration.InsertedAt)
+	data.InsertedAt = NewTimestampValue(integration.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, integration.Labels)...)
 
 	if integration.Enabled != nil {
 		data.Enabled = types.BoolValue(*integration.Enabled)
@@ -165,6 +197,7 @@ func (r *IntegrationResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *IntegrationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -189,23 +222,17 @@ func (r *IntegrationResource) Update(ctx context.Context, req resource.UpdateReq
 		integration.SyncIntervalMinutes = &interval
 	}
 
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	integration.Labels = labels
+
 	updated, err := r.client.UpdateIntegration(data.ID.ValueString(), integration)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			created, createErr := r.client.CreateIntegration(integration)
-			if createErr != nil {
-				resp.Diagnostics.AddError("Failed to create integration (after 404 on update)", createErr.Error())
-				return
-			}
-			data.ID = types.StringValue(created.ID)
-			data.InsertedAt = types.StringValue(created.InsertedAt)
-			if created.Enabled != nil {
-				data.Enabled = types.BoolValue(*created.Enabled)
-			}
-			if created.SyncIntervalMinutes != nil {
-				data.SyncIntervalMinutes = types.Int64Value(int64(*created.SyncIntervalMinutes))
-			}
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.Append(deletedDuringUpdate("integration", data.ID.ValueString()))
 			return
 		}
 		resp.Diagnostics.AddError("Failed to update integration", err.Error())
@@ -221,7 +248,10 @@ func (r *IntegrationResource) Update(ctx context.Context, req resource.UpdateReq
 		data.SyncIntervalMinutes = types.Int64Value(int64(*updated.SyncIntervalMinutes))
 	}
 
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, updated.Labels)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *IntegrationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -240,5 +270,5 @@ func (r *IntegrationResource) Delete(ctx context.Context, req resource.DeleteReq
 }
 
 func (r *IntegrationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithIdentity(ctx, r.client, req, resp)
 }
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const defaultIntegrationSyncTimeout = 10 * time.Minute
+
+var _ action.Action = &IntegrationSyncAction{}
+var _ action.ActionWithConfigure = &IntegrationSyncAction{}
+
+// IntegrationSyncAction triggers an immediate sync for an integration and
+// waits for it to finish.
+type IntegrationSyncAction struct {
+	client       *Client
+	pollInterval time.Duration
+}
+
+// IntegrationSyncActionModel describes the action data model.
+type IntegrationSyncActionModel struct {
+	IntegrationID  types.String `tfsdk:"integration_id"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+	FailOnErrors   types.Bool   `tfsdk:"fail_on_errors"`
+}
+
+// NewIntegrationSyncAction creates a new integration sync action.
+func NewIntegrationSyncAction() action.Action {
+	return &IntegrationSyncAction{
+		pollInterval: 5 * time.Second,
+	}
+}
+
+func (a *IntegrationSyncAction) Metadata(ctx context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_integration_sync"
+}
+
+func (a *IntegrationSyncAction) Schema(ctx context.Context, req action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Triggers an immediate sync for an integration, waits for it to finish and reports how many records were created, updated or failed. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"integration_id": schema.StringAttribute{
+				Description: "The ID of the integration to sync.",
+				Required:    true,
+			},
+			"timeout_seconds": schema.Int64Attribute{
+				Description: "How long to wait for the sync to finish, in seconds. Defaults to 600.",
+				Optional:    true,
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"fail_on_errors": schema.BoolAttribute{
+				Description: "Whether to fail the action when any record fails to sync. When false, failed records are reported as a warning. Defaults to false.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (a *IntegrationSyncAction) Configure(ctx context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Action Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	a.client = client
+}
+
+func (a *IntegrationSyncAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	var data IntegrationSyncActionModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	integrationID := data.IntegrationID.ValueString()
+
+	sync, err := a.client.SyncIntegration(integrationID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("integration_id"),
+				"Integration Not Found",
+				fmt.Sprintf("No integration with ID %q exists.", integrationID),
+			)
+			return
+		}
+		resp.Diagnostics.AddError("Failed to start integration sync", err.Error())
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Started sync of integration %s", integrationID),
+	})
+
+	timeout := defaultIntegrationSyncTimeout
+	if !data.TimeoutSeconds.IsNull() {
+		timeout = time.Duration(data.TimeoutSeconds.ValueInt64()) * time.Second
+	}
+
+	err = pollUntil(ctx, a.pollInterval, timeout,
+		func() bool {
+			return sync.Status == SyncStatusCompleted || sync.Status == SyncStatusFailed
+		},
+		func() error {
+			next, err := a.client.GetIntegrationSync(integrationID, sync.ID)
+			if err != nil {
+				return err
+			}
+			if next.Status != sync.Status {
+				resp.SendProgress(action.InvokeProgressEvent{
+					Message: fmt.Sprintf("Sync of integration %s is %s", integrationID, next.Status),
+				})
+			}
+			sync = next
+			return nil
+		},
+	)
+	switch {
+	case errors.Is(err, errPollTimeout):
+		resp.Diagnostics.AddError(
+			"Timed Out Waiting for Integration Sync",
+			fmt.Sprintf("Sync of integration %s was still %s after %s.", integrationID, sync.Status, timeout),
+		)
+		return
+	case ctx.Err() != nil:
+		resp.Diagnostics.AddError("Integration Sync Cancelled", ctx.Err().Error())
+		return
+	case err != nil:
+		resp.Diagnostics.AddError("Failed to read integration sync status", err.Error())
+		return
+	}
+
+	if sync.Status == SyncStatusFailed {
+		detail := fmt.Sprintf("Sync of integration %s failed.", integrationID)
+		if sync.Message != nil {
+			detail = fmt.Sprintf("Sync of integration %s failed: %s", integrationID, *sync.Message)
+		}
+		resp.Diagnostics.AddError("Integration Sync Failed", detail)
+		return
+	}
+
+	summary := fmt.Sprintf("Sync of integration %s finished: %d created, %d updated, %d failed",
+		integrationID, sync.RecordsCreated, sync.RecordsUpdated, sync.RecordsFailed)
+	resp.SendProgress(action.InvokeProgressEvent{Message: summary})
+
+	if sync.RecordsFailed > 0 {
+		if data.FailOnErrors.ValueBool() {
+			resp.Diagnostics.AddError("Integration Sync Had Failed Records", summary+".")
+		} else {
+			resp.Diagnostics.AddWarning("Integration Sync Had Failed Records", summary+".")
+		}
+	}
+}
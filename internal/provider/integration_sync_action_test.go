@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newIntegrationSyncServer(t *testing.T, recordsFailed string) *httptest.Server {
+	var mu sync.Mutex
+	polls := 0
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/integrations/int-123/sync":
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id": "sync-1", "integration_id": "int-123", "status": "pending"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/integrations/int-123/syncs/sync-1":
+			polls++
+			if polls < 2 {
+				w.Write([]byte(`{"id": "sync-1", "integration_id": "int-123", "status": "running"}`))
+				return
+			}
+			w.Write([]byte(`{
+				"id": "sync-1",
+				"integration_id": "int-123",
+				"status": "completed",
+				"records_created": 12,
+				"records_updated": 3,
+				"records_failed": ` + recordsFailed + `
+			}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestIntegrationSyncAction_Invoke(t *testing.T) {
+	server := newIntegrationSyncServer(t, "0")
+	defer server.Close()
+
+	a := &IntegrationSyncAction{
+		client:       NewClient("test-token", server.URL),
+		pollInterval: time.Millisecond,
+	}
+
+	req := newActionInvokeRequest(t, a, &IntegrationSyncActionModel{
+		IntegrationID:  types.StringValue("int-123"),
+		TimeoutSeconds: types.Int64Null(),
+		FailOnErrors:   types.BoolNull(),
+	})
+
+	var messages []string
+	resp := &action.InvokeResponse{
+		SendProgress: func(event action.InvokeProgressEvent) {
+			messages = append(messages, event.Message)
+		},
+	}
+
+	a.Invoke(context.Background(), req, resp)
+
+	if len(resp.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	want := "Sync of integration int-123 finished: 12 created, 3 updated, 0 failed"
+	if len(messages) == 0 || messages[len(messages)-1] != want {
+		t.Errorf("expected final progress message %q, got %v", want, messages)
+	}
+}
+
+func TestIntegrationSyncAction_Invoke_failedRecords(t *testing.T) {
+	tests := []struct {
+		name         string
+		failOnErrors bool
+		wantError    bool
+	}{
+		{name: "warning by default", failOnErrors: false, wantError: false},
+		{name: "error when fail_on_errors", failOnErrors: true, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newIntegrationSyncServer(t, "2")
+			defer server.Close()
+
+			a := &IntegrationSyncAction{
+				client:       NewClient("test-token", server.URL),
+				pollInterval: time.Millisecond,
+			}
+
+			req := newActionInvokeRequest(t, a, &IntegrationSyncActionModel{
+				IntegrationID:  types.StringValue("int-123"),
+				TimeoutSeconds: types.Int64Null(),
+				FailOnErrors:   types.BoolValue(tt.failOnErrors),
+			})
+			resp := &action.InvokeResponse{SendProgress: func(action.InvokeProgressEvent) {}}
+
+			a.Invoke(context.Background(), req, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Fatalf("expected error %v, got diagnostics: %v", tt.wantError, resp.Diagnostics)
+			}
+			if !tt.wantError && resp.Diagnostics.WarningsCount() != 1 {
+				t.Errorf("expected one warning, got diagnostics: %v", resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = IPAddressType{}
+var _ basetypes.StringValuableWithSemanticEquals = IPAddressValue{}
+var _ xattr.ValidateableAttribute = IPAddressValue{}
+
+// IPAddressType is a string attribute type holding an IPv4 or IPv6 address,
+// or a DNS hostname. Addresses are compared by value, so "2001:db8::1" and
+// "2001:0db8:0000:0000:0000:0000:0000:0001" are equal. Whether a hostname is
+// allowed depends on the resource, so it is checked there.
+type IPAddressType struct {
+	basetypes.StringType
+}
+
+func (t IPAddressType) Equal(o attr.Type) bool {
+	other, ok := o.(IPAddressType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t IPAddressType) String() string {
+	return "IPAddressType"
+}
+
+func (t IPAddressType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return IPAddressValue{StringValue: in}, nil
+}
+
+func (t IPAddressType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return IPAddressValue{StringValue: stringValue}, nil
+}
+
+func (t IPAddressType) ValueType(ctx context.Context) attr.Value {
+	return IPAddressValue{}
+}
+
+// IPAddressValue is the value of an IPAddressType attribute.
+type IPAddressValue struct {
+	basetypes.StringValue
+}
+
+// NewIPAddressValue returns a known IP address value.
+func NewIPAddressValue(value string) IPAddressValue {
+	return IPAddressValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v IPAddressValue) Equal(o attr.Value) bool {
+	other, ok := o.(IPAddressValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v IPAddressValue) Type(ctx context.Context) attr.Type {
+	return IPAddressType{}
+}
+
+// StringSemanticEquals reports whether both values are the same address, or
+// the same hostname ignoring case and a trailing dot.
+func (v IPAddressValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(IPAddressValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return sameIPAddress(v.ValueString(), newValue.ValueString()), diags
+}
+
+// ValidateAttribute reports configured values that are neither an IP address
+// nor a valid hostname.
+func (v IPAddressValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if v.IsIP() || isHostname(v.ValueString()) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid IP Address",
+		fmt.Sprintf("%q is not an IPv4 or IPv6 address. Use a value such as 192.0.2.10 or 2001:db8::10.", v.ValueString()),
+	)
+}
+
+// IsIP reports whether the value is an IP address rather than a hostname.
+func (v IPAddressValue) IsIP() bool {
+	_, err := netip.ParseAddr(v.ValueString())
+	return err == nil
+}
+
+// sameIPAddress reports whether a and b name the same IP address or hostname.
+func sameIPAddress(a, b string) bool {
+	addrA, errA := netip.ParseAddr(a)
+	addrB, errB := netip.ParseAddr(b)
+	if errA == nil && errB == nil {
+		return addrA == addrB
+	}
+	if errA == nil || errB == nil {
+		return false
+	}
+
+	return strings.EqualFold(strings.TrimSuffix(a, "."), strings.TrimSuffix(b, "."))
+}
+
+// isHostname reports whether s is a valid DNS hostname (RFC 1123). A name
+// whose last label is all digits is rejected, so a mistyped IPv4 address
+// such as 10.0.0.300 is not taken for a hostname.
+func isHostname(s string) bool {
+	s = strings.TrimSuffix(s, ".")
+	if s == "" || len(s) > 253 {
+		return false
+	}
+
+	labels := strings.Split(s, ".")
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 63 {
+			return false
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+
+	return strings.Trim(labels[len(labels)-1], "0123456789") != ""
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIPAddressValue_StringSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		current, proposed string
+		want              bool
+	}{
+		{"10.0.0.1", "10.0.0.1", true},
+		{"10.0.0.1", "10.0.0.2", false},
+		{"2001:db8::1", "2001:0db8:0000:0000:0000:0000:0000:0001", true},
+		{"2001:db8::1", "2001:DB8::1", true},
+		{"2001:db8::1", "2001:db8::2", false},
+		{"::ffff:10.0.0.1", "10.0.0.1", false},
+		{"olt-1.example.net", "OLT-1.example.net.", true},
+		{"olt-1.example.net", "10.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		got, diags := NewIPAddressValue(tt.current).StringSemanticEquals(ctx, NewIPAddressValue(tt.proposed))
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if got != tt.want {
+			t.Errorf("StringSemanticEquals(%q, %q) = %v, want %v", tt.current, tt.proposed, got, tt.want)
+		}
+	}
+}
+
+func TestIPAddressValue_ValidateAttribute(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		value     string
+		wantError bool
+	}{
+		{"192.0.2.10", false},
+		{"2001:db8::10", false},
+		{"fe80::1%eth0", false},
+		{"olt-1.example.net", false},
+		{"router", false},
+		{"10.0.0.300", true},
+		{"10.0.0", true},
+		{"192.168.1.1/24", true},
+		{"-bad-.example.net", true},
+		{"under_score.example.net", true},
+		{"", true},
+	}
+
+	for _, tt := range tests {
+		resp := &xattr.ValidateAttributeResponse{}
+		NewIPAddressValue(tt.value).ValidateAttribute(ctx, xattr.ValidateAttributeRequest{Path: path.Root("ip_address")}, resp)
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Errorf("ValidateAttribute(%q): expected error %v, got: %v", tt.value, tt.wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestDeviceResource_ValidateConfig_hostname(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewDeviceResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	tests := []struct {
+		address       string
+		allowHostname types.Bool
+		wantError     bool
+	}{
+		{"10.0.0.1", types.BoolNull(), false},
+		{"olt-1.example.net", types.BoolNull(), true},
+		{"olt-1.example.net", types.BoolValue(false), true},
+		{"olt-1.example.net", types.BoolValue(true), false},
+		{"olt-1.example.net", types.BoolUnknown(), false},
+	}
+
+	for _, tt := range tests {
+		// tfsdk.Plan is used only to encode the model into a raw value.
+		raw := tfsdk.Plan{Schema: schemaResp.Schema}
+		if diags := raw.Set(ctx, &DeviceResourceModel{
+			IPAddress:     NewIPAddressValue(tt.address),
+			AllowHostname: tt.allowHostname,
+			Labels:        types.MapNull(types.StringType),
+			LabelsAll:     types.MapNull(types.StringType),
+		}); diags.HasError() {
+			t.Fatalf("unexpected error building config: %v", diags)
+		}
+
+		resp := &resource.ValidateConfigResponse{}
+		NewDeviceResource().(*DeviceResource).ValidateConfig(ctx, resource.ValidateConfigRequest{
+			Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: raw.Raw},
+		}, resp)
+
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Errorf("%q with allow_hostname %s: expected error %v, got: %v", tt.address, tt.allowHostname, tt.wantError, resp.Diagnostics)
+		}
+	}
+}
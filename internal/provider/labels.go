@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// labelsAttribute returns the schema for the user-managed labels map.
+func labelsAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Description: "Labels to apply to this resource. Merged with the provider's default_labels, with these values taking precedence.",
+		ElementType: types.StringType,
+		Optional:    true,
+	}
+}
+
+// labelsAllAttribute returns the schema for the effective labels map.
+func labelsAllAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		Description: "All labels on this resource, including those inherited from the provider's default_labels.",
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+}
+
+// modifyPlanLabels sets labels_all in the plan to the provider default labels
+// merged with the resource's own labels.
+func modifyPlanLabels(ctx context.Context, client *Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var labels types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("labels"), &labels)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if labels.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	merged := make(map[string]string)
+	if client != nil {
+		for k, v := range client.DefaultLabels {
+			merged[k] = v
+		}
+	}
+
+	for k, v := range labels.Elements() {
+		s, ok := v.(types.String)
+		if !ok || s.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), types.MapUnknown(types.StringType))...)
+			return
+		}
+		merged[k] = s.ValueString()
+	}
+
+	labelsAll, diags := types.MapValueFrom(ctx, types.StringType, merged)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("labels_all"), labelsAll)...)
+}
+
+// labelsFromModel converts the planned labels_all map into the API representation.
+func labelsFromModel(ctx context.Context, labelsAll types.Map) (map[string]string, diag.Diagnostics) {
+	labels := make(map[string]string)
+	if labelsAll.IsNull() || labelsAll.IsUnknown() {
+		return labels, nil
+	}
+
+	diags := labelsAll.ElementsAs(ctx, &labels, false)
+	return labels, diags
+}
+
+// setLabelsFromAPI maps API labels back to the Terraform model. labels_all
+// mirrors the API, while labels keeps only the keys the configuration manages
+// so that labels inherited from default_labels do not show up as drift.
+//
+// A response without labels leaves both maps as they are, so the planned
+// labels_all survives Create and Update against an API that doesn't echo
+// labels. labels_all only falls back to an empty map when it has no value.
+func setLabelsFromAPI(ctx context.Context, labels, labelsAll *types.Map, apiLabels map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if apiLabels == nil {
+		if labelsAll.IsNull() || labelsAll.IsUnknown() {
+			*labelsAll = types.MapValueMust(types.StringType, map[string]attr.Value{})
+		}
+		return diags
+	}
+
+	all, d := types.MapValueFrom(ctx, types.StringType, apiLabels)
+	diags.Append(d...)
+	*labelsAll = all
+
+	if labels.IsNull() || labels.IsUnknown() {
+		return diags
+	}
+
+	managed := make(map[string]string)
+	for k := range labels.Elements() {
+		if v, ok := apiLabels[k]; ok {
+			managed[k] = v
+		}
+	}
+
+	kept, d := types.MapValueFrom(ctx, types.StringType, managed)
+	diags.Append(d...)
+	*labels = kept
+
+	return diags
+}
+
+// labelsForImport builds labels and labels_all for an object that has no
+// prior state, such as a list result. Labels inherited unchanged from the
+// provider's default_labels are left out of labels so that generated
+// configuration does not repeat them.
+func labelsForImport(ctx context.Context, client *Client, apiLabels map[string]string) (types.Map, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if apiLabels == nil {
+		apiLabels = map[string]string{}
+	}
+
+	managed := make(map[string]string)
+	for k, v := range apiLabels {
+		if client != nil {
+			if def, ok := client.DefaultLabels[k]; ok && def == v {
+				continue
+			}
+		}
+		managed[k] = v
+	}
+
+	labels := types.MapNull(types.StringType)
+	if len(managed) > 0 {
+		var d diag.Diagnostics
+		labels, d = types.MapValueFrom(ctx, types.StringType, managed)
+		diags.Append(d...)
+	}
+
+	labelsAll, d := types.MapValueFrom(ctx, types.StringType, apiLabels)
+	diags.Append(d...)
+
+	return labels, labelsAll, diags
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSetLabelsFromAPI(t *testing.T) {
+	ctx := context.Background()
+
+	labels := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"team":  types.StringValue("towers"),
+		"owner": types.StringValue("alice"),
+	})
+	var labelsAll types.Map
+
+	apiLabels := map[string]string{
+		"environment": "production",
+		"team":        "noc",
+	}
+
+	diags := setLabelsFromAPI(ctx, &labels, &labelsAll, apiLabels)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if len(labelsAll.Elements()) != 2 {
+		t.Errorf("expected 2 labels_all entries, got %d", len(labelsAll.Elements()))
+	}
+
+	// Only keys managed by the configuration are kept, with the API's values.
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"team": types.StringValue("noc"),
+	})
+	if !labels.Equal(want) {
+		t.Errorf("expected labels %v, got %v", want, labels)
+	}
+}
+
+func TestSetLabelsFromAPI_NullLabels(t *testing.T) {
+	ctx := context.Background()
+
+	labels := types.MapNull(types.StringType)
+	var labelsAll types.Map
+
+	diags := setLabelsFromAPI(ctx, &labels, &labelsAll, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if !labels.IsNull() {
+		t.Errorf("expected labels to stay null, got %v", labels)
+	}
+	if labelsAll.IsNull() || len(labelsAll.Elements()) != 0 {
+		t.Errorf("expected empty labels_all, got %v", labelsAll)
+	}
+}
+
+func TestSetLabelsFromAPI_LabelsOmitted(t *testing.T) {
+	ctx := context.Background()
+
+	labels := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"team": types.StringValue("towers"),
+	})
+	labelsAll := types.MapValueMust(types.StringType, map[string]attr.Value{
+		"environment": types.StringValue("production"),
+		"team":        types.StringValue("towers"),
+	})
+	wantLabels, wantLabelsAll := labels, labelsAll
+
+	// An API that doesn't echo labels leaves the planned values in place.
+	diags := setLabelsFromAPI(ctx, &labels, &labelsAll, nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if !labels.Equal(wantLabels) {
+		t.Errorf("expected labels %v, got %v", wantLabels, labels)
+	}
+	if !labelsAll.Equal(wantLabelsAll) {
+		t.Errorf("expected labels_all %v, got %v", wantLabelsAll, labelsAll)
+	}
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"iter"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+)
+
+// streamListResults returns a stream with one list result per item, stopping
+// once the request limit is reached. build fills in the display name and,
+// when requested, the resource state; the identity is set from id.
+func streamListResults[T any](ctx context.Context, client *Client, req list.ListRequest, items []T, id func(T) string, build func(T, *list.ListResult)) iter.Seq[list.ListResult] {
+	return func(push func(list.ListResult) bool) {
+		var count int64
+		for _, item := range items {
+			if req.Limit > 0 && count >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.Diagnostics.Append(setResourceIdentity(ctx, client, result.Identity, id(item))...)
+			build(item, &result)
+
+			if !push(result) {
+				return
+			}
+			count++
+		}
+	}
+}
+
+// listErrorStream returns a stream containing a single error result.
+func listErrorStream(summary, detail string) iter.Seq[list.ListResult] {
+	var diags diag.Diagnostics
+	diags.AddError(summary, detail)
+	return list.ListResultsStreamDiagnostics(diags)
+}
@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &MaintenanceWindowListResource{}
+var _ list.ListResourceWithConfigure = &MaintenanceWindowListResource{}
+
+// MaintenanceWindowListResource lists existing maintenance windows for terraform query.
+type MaintenanceWindowListResource struct {
+	client *Client
+}
+
+// MaintenanceWindowListResourceModel describes the list filters.
+type MaintenanceWindowListResourceModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+	SiteID     types.String `tfsdk:"site_id"`
+	DeviceID   types.String `tfsdk:"device_id"`
+}
+
+// NewMaintenanceWindowListResource creates a new maintenance window list resource.
+func NewMaintenanceWindowListResource() list.ListResource {
+	return &MaintenanceWindowListResource{}
+}
+
+func (r *MaintenanceWindowListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_maintenance_window"
+}
+
+func (r *MaintenanceWindowListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing TowerOps maintenance windows.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Description: "Only list maintenance windows whose name starts with this prefix.",
+				Optional:    true,
+			},
+			"site_id": schema.StringAttribute{
+				Description: "Only list maintenance windows for this site.",
+				Optional:    true,
+			},
+			"device_id": schema.StringAttribute{
+				Description: "Only list maintenance windows for this device.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *MaintenanceWindowListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *MaintenanceWindowListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config MaintenanceWindowListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	windows, err := r.client.ListMaintenanceWindows()
+	if err != nil {
+		stream.Results = listErrorStream("Failed to list maintenance windows", err.Error())
+		return
+	}
+
+	var matched []MaintenanceWindowAPI
+	for _, window := range windows {
+		if !strings.HasPrefix(window.Name, config.NamePrefix.ValueString()) {
+			continue
+		}
+		if !config.SiteID.IsNull() && (window.SiteID == nil || *window.SiteID != config.SiteID.ValueString()) {
+			continue
+		}
+		if !config.DeviceID.IsNull() && (window.DeviceID == nil || *window.DeviceID != config.DeviceID.ValueString()) {
+			continue
+		}
+		matched = append(matched, window)
+	}
+
+	stream.Results = streamListResults(ctx, r.client, req, matched,
+		func(window MaintenanceWindowAPI) string { return window.ID },
+		func(window MaintenanceWindowAPI, result *list.ListResult) {
+			result.DisplayName = window.Name
+			if !req.IncludeResource {
+				return
+			}
+
+			data := MaintenanceWindowResourceModel{
+				ID:         types.StringValue(window.ID),
+				Name:       types.StringValue(window.Name),
+				StartsAt:   NewTimestampValue(window.StartsAt),
+				EndsAt:     NewTimestampValue(window.EndsAt),
+				InsertedAt: NewTimestampValue(window.InsertedAt),
+			}
+			if window.Reason != nil {
+				data.Reason = types.StringValue(*window.Reason)
+			}
+			if window.SuppressAlerts != nil {
+				data.SuppressAlerts = types.BoolValue(*window.SuppressAlerts)
+			}
+			if window.SiteID != nil {
+				data.SiteID = types.StringValue(*window.SiteID)
+			}
+			if window.DeviceID != nil {
+				data.DeviceID = types.StringValue(*window.DeviceID)
+			}
+
+			var d diag.Diagnostics
+			data.Labels, data.LabelsAll, d = labelsForImport(ctx, r.client, window.Labels)
+			result.Diagnostics.Append(d...)
+
+			result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+		},
+	)
+}
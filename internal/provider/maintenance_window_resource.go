@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
@@ -15,7 +14,11 @@ import (
 )
 
 var _ resource.Resource = &MaintenanceWindowResource{}
+var _ resource.ResourceWithUpgradeState = &MaintenanceWindowResource{}
 var _ resource.ResourceWithImportState = &MaintenanceWindowResource{}
+var _ resource.ResourceWithIdentity = &MaintenanceWindowResource{}
+var _ resource.ResourceWithModifyPlan = &MaintenanceWindowResource{}
+var _ resource.ResourceWithConfigValidators = &MaintenanceWindowResource{}
 
 // MaintenanceWindowResource defines the resource implementation.
 type MaintenanceWindowResource struct {
@@ -24,15 +27,17 @@ type MaintenanceWindowResource struct {
 
 // MaintenanceWindowResourceModel describes the resource data model.
 type MaintenanceWindowResourceModel struct {
-	ID             types.String `tfsdk:"id"`
-	Name           types.String `tfsdk:"name"`
-	Reason         types.String `tfsdk:"reason"`
-	StartsAt       types.String `tfsdk:"starts_at"`
-	EndsAt         types.String `tfsdk:"ends_at"`
-	SuppressAlerts types.Bool   `tfsdk:"suppress_alerts"`
-	SiteID         types.String `tfsdk:"site_id"`
-	DeviceID       types.String `tfsdk:"device_id"`
-	InsertedAt     types.String `tfsdk:"inserted_at"`
+	ID             types.String   `tfsdk:"id"`
+	Name           types.String   `tfsdk:"name"`
+	Reason         types.String   `tfsdk:"reason"`
+	StartsAt       TimestampValue `tfsdk:"starts_at"`
+	EndsAt         TimestampValue `tfsdk:"ends_at"`
+	SuppressAlerts types.Bool     `tfsdk:"suppress_alerts"`
+	SiteID         types.String   `tfsdk:"site_id"`
+	DeviceID       types.String   `tfsdk:"device_id"`
+	Labels         types.Map      `tfsdk:"labels"`
+	LabelsAll      types.Map      `tfsdk:"labels_all"`
+	InsertedAt     TimestampValue `tfsdk:"inserted_at"`
 }
 
 // NewMaintenanceWindowResource creates a new maintenance window resource.
@@ -44,8 +49,13 @@ func (r *MaintenanceWindowResource) Metadata(ctx context.Context, req resource.M
 	resp.TypeName = req.ProviderTypeName + "_maintenance_window"
 }
 
+func (r *MaintenanceWindowResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema()
+}
+
 func (r *MaintenanceWindowResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages a TowerOps maintenance window. Maintenance windows suppress alerts during planned work periods.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -64,11 +74,13 @@ func (r *MaintenanceWindowResource) Schema(ctx context.Context, req resource.Sch
 				Optional:    true,
 			},
 			"starts_at": schema.StringAttribute{
-				Description: "The start time of the maintenance window in ISO 8601 format (e.g. 2024-01-15T02:00:00Z).",
+				CustomType:  TimestampType{},
+				Description: "The start time of the maintenance window as an RFC 3339 timestamp (e.g. 2024-01-15T02:00:00Z).",
 				Required:    true,
 			},
 			"ends_at": schema.StringAttribute{
-				Description: "The end time of the maintenance window in ISO 8601 format (e.g. 2024-01-15T06:00:00Z).",
+				CustomType:  TimestampType{},
+				Description: "The end time of the maintenance window as an RFC 3339 timestamp (e.g. 2024-01-15T06:00:00Z). Must be after starts_at, and within the provider's maintenance_window_max_duration (30 days by default) of it.",
 				Required:    true,
 			},
 			"suppress_alerts": schema.BoolAttribute{
@@ -78,14 +90,17 @@ func (r *MaintenanceWindowResource) Schema(ctx context.Context, req resource.Sch
 				Default:     booldefault.StaticBool(true),
 			},
 			"site_id": schema.StringAttribute{
-				Description: "The site to apply the maintenance window to. If omitted, applies to all sites.",
+				Description: "The site to apply the maintenance window to. If omitted, applies to all sites. When device_id is also set, the window only covers that device while it is at this site; planning warns when the device is at another site.",
 				Optional:    true,
 			},
 			"device_id": schema.StringAttribute{
 				Description: "The device to apply the maintenance window to. If omitted, applies to all devices.",
 				Optional:    true,
 			},
+			"labels":     labelsAttribute(),
+			"labels_all": labelsAllAttribute(),
 			"inserted_at": schema.StringAttribute{
+				CustomType:  TimestampType{},
 				Description: "The timestamp when the maintenance window was created.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
@@ -96,6 +111,12 @@ func (r *MaintenanceWindowResource) Schema(ctx context.Context, req resource.Sch
 	}
 }
 
+func (r *MaintenanceWindowResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r,
+		upgradeFromV0,
+	)
+}
+
 func (r *MaintenanceWindowResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -113,6 +134,21 @@ func (r *MaintenanceWindowResource) Configure(ctx context.Context, req resource.
 	r.client = client
 }
 
+func (r *MaintenanceWindowResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		maintenanceWindowOrderValidator{},
+	}
+}
+
+func (r *MaintenanceWindowResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	validateMaintenanceWindowPlan(ctx, r.client, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modifyPlanLabels(ctx, r.client, req, resp)
+}
+
 func (r *MaintenanceWindowResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data MaintenanceWindowResourceModel
 
@@ -147,6 +183,13 @@ func (r *MaintenanceWindowResource) Create(ctx context.Context, req resource.Cre
 		window.DeviceID = &deviceID
 	}
 
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	window.Labels = labels
+
 	created, err := r.client.CreateMaintenanceWindow(window)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create maintenance window", err.Error())
@@ -154,13 +197,15 @@ func (r *MaintenanceWindowResource) Create(ctx context.Context, req resource.Cre
 	}
 
 	data.ID = types.StringValue(created.ID)
-	data.InsertedAt = types.StringValue(created.InsertedAt)
+	data.InsertedAt = NewTimestampValue(created.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, created.Labels)...)
 
 	if created.SuppressAlerts != nil {
 		data.SuppressAlerts = types.BoolValue(*created.SuppressAlerts)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *MaintenanceWindowResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -182,9 +227,10 @@ func (r *MaintenanceWindowResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	data.Name = types.StringValue(window.Name)
-	data.StartsAt = types.StringValue(window.StartsAt)
-	data.EndsAt = types.StringValue(window.EndsAt)
-	data.InsertedAt = types.StringValue(window.InsertedAt)
+	data.StartsAt = NewTimestampValue(window.StartsAt)
+	data.EndsAt = NewTimestampValue(window.EndsAt)
+	data.InsertedAt = NewTimestampValue(window.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, window.Labels)...)
 
 	if window.Reason != nil {
 		data.Reason = types.StringValue(*window.Reason)
@@ -209,6 +255,7 @@ func (r *MaintenanceWindowResource) Read(ctx context.Context, req resource.ReadR
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *MaintenanceWindowResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -245,20 +292,17 @@ func (r *MaintenanceWindowResource) Update(ctx context.Context, req resource.Upd
 		window.DeviceID = &deviceID
 	}
 
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	window.Labels = labels
+
 	updated, err := r.client.UpdateMaintenanceWindow(data.ID.ValueString(), window)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			created, createErr := r.client.CreateMaintenanceWindow(window)
-			if createErr != nil {
-				resp.Diagnostics.AddError("Failed to create maintenance window (after 404 on update)", createErr.Error())
-				return
-			}
-			data.ID = types.StringValue(created.ID)
-			data.InsertedAt = types.StringValue(created.InsertedAt)
-			if created.SuppressAlerts != nil {
-				data.SuppressAlerts = types.BoolValue(*created.SuppressAlerts)
-			}
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.Append(deletedDuringUpdate("maintenance window", data.ID.ValueString()))
 			return
 		}
 		resp.Diagnostics.AddError("Failed to update maintenance window", err.Error())
@@ -266,14 +310,17 @@ func (r *MaintenanceWindowResource) Update(ctx context.Context, req resource.Upd
 	}
 
 	data.Name = types.StringValue(updated.Name)
-	data.StartsAt = types.StringValue(updated.StartsAt)
-	data.EndsAt = types.StringValue(updated.EndsAt)
+	data.StartsAt = NewTimestampValue(updated.StartsAt)
+	data.EndsAt = NewTimestampValue(updated.EndsAt)
 
 	if updated.SuppressAlerts != nil {
 		data.SuppressAlerts = types.BoolValue(*updated.SuppressAlerts)
 	}
 
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, updated.Labels)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *MaintenanceWindowResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -292,5 +339,5 @@ func (r *MaintenanceWindowResource) Delete(ctx context.Context, req resource.Del
 }
 
 func (r *MaintenanceWindowResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	importStateWithIdentity(ctx, r.client, req, resp)
 }
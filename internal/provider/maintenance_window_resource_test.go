@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccMaintenanceWindowResource_timestampFormat(t *testing.T) {
+	var mu sync.Mutex
+	created := false
+
+	// The API normalizes timestamps to microsecond precision and a numeric
+	// offset, which must not show up as a diff.
+	window := MaintenanceWindowAPI{
+		ID:         "window-123",
+		Name:       "Tower climb",
+		StartsAt:   "2024-03-15T02:00:00.000000+00:00",
+		EndsAt:     "2024-03-15T06:00:00.000000+00:00",
+		InsertedAt: "2024-03-01T12:00:00.000000+00:00",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/maintenance_windows":
+			created = true
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(window)
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/maintenance_windows/window-123" && created:
+			json.NewEncoder(w).Encode(window)
+
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/maintenance_windows/window-123":
+			created = false
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(server.URL),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMaintenanceWindowResourceConfig(server.URL, "2024-03-15T02:00:00Z", "2024-03-15T06:00:00Z"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("towerops_maintenance_window.test", "starts_at", "2024-03-15T02:00:00Z"),
+					resource.TestCheckResourceAttr("towerops_maintenance_window.test", "ends_at", "2024-03-15T06:00:00Z"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMaintenanceWindowResourceConfig(apiURL, startsAt, endsAt string) string {
+	return fmt.Sprintf(`
+provider "towerops" {
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
+}
+
+resource "towerops_maintenance_window" "test" {
+  name      = "Tower climb"
+  starts_at = %q
+  ends_at   = %q
+}
+`, apiURL, startsAt, endsAt)
+}
+
+func TestAccMaintenanceWindowResource_endsBeforeStart(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories("http://localhost"),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMaintenanceWindowResourceConfig("http://localhost", "2024-03-15T06:00:00Z", "2024-03-15T02:00:00Z"),
+				ExpectError: regexp.MustCompile(`must be after starts_at`),
+			},
+		},
+	})
+}
+
+// newMaintenanceWindowPlan builds config and plan values for a maintenance
+// window being created.
+func newMaintenanceWindowPlan(t *testing.T, model MaintenanceWindowResourceModel) (tfsdk.Config, tfsdk.Plan) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewMaintenanceWindowResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	model.Name = types.StringValue("Tower climb")
+	model.Labels = types.MapNull(types.StringType)
+	model.LabelsAll = types.MapNull(types.StringType)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := plan.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected error building plan: %v", diags)
+	}
+
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw}, plan
+}
+
+func TestMaintenanceWindowOrderValidator(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		startsAt, endsAt string
+		wantError        bool
+	}{
+		{"2024-03-15T02:00:00Z", "2024-03-15T06:00:00Z", false},
+		{"2024-03-15T02:00:00Z", "2024-03-15T02:00:00Z", true},
+		{"2024-03-15T06:00:00Z", "2024-03-15T02:00:00Z", true},
+		// The same instant written with another offset.
+		{"2024-03-15T02:00:00Z", "2024-03-14T21:00:00-05:00", true},
+		{"2024-03-15T02:00:00Z", "2024-03-14T23:00:00-05:00", false},
+	}
+
+	for _, tt := range tests {
+		config, _ := newMaintenanceWindowPlan(t, MaintenanceWindowResourceModel{
+			StartsAt: NewTimestampValue(tt.startsAt),
+			EndsAt:   NewTimestampValue(tt.endsAt),
+		})
+
+		resp := &fwresource.ValidateConfigResponse{}
+		maintenanceWindowOrderValidator{}.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, resp)
+
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Errorf("%s to %s: expected error %v, got: %v", tt.startsAt, tt.endsAt, tt.wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestMaintenanceWindowResource_ModifyPlan_maxDuration(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		maxDuration time.Duration
+		endsAt      string
+		wantError   bool
+	}{
+		{"within default", 0, "2024-04-10T02:00:00Z", false},
+		{"beyond default", 0, "2024-05-15T02:00:00Z", true},
+		{"within configured", 4 * time.Hour, "2024-03-15T06:00:00Z", false},
+		{"beyond configured", 4 * time.Hour, "2024-03-15T06:00:01Z", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, plan := newMaintenanceWindowPlan(t, MaintenanceWindowResourceModel{
+				StartsAt: NewTimestampValue("2024-03-15T02:00:00Z"),
+				EndsAt:   NewTimestampValue(tt.endsAt),
+			})
+
+			r := &MaintenanceWindowResource{client: &Client{MaintenanceWindowMaxDuration: tt.maxDuration}}
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Config: config, Plan: plan}, resp)
+
+			if resp.Diagnostics.HasError() != tt.wantError {
+				t.Errorf("expected error %v, got: %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestMaintenanceWindowResource_ModifyPlan_siteConflict(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/devices/device-1":
+			json.NewEncoder(w).Encode(Device{ID: "device-1", SiteID: strPtr("site-1"), IPAddress: "10.0.0.1"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name, siteID, deviceID string
+		wantWarning            bool
+	}{
+		{"same site", "site-1", "device-1", false},
+		{"other site", "site-2", "device-1", true},
+		{"device not found", "site-2", "device-9", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, plan := newMaintenanceWindowPlan(t, MaintenanceWindowResourceModel{
+				StartsAt: NewTimestampValue("2024-03-15T02:00:00Z"),
+				EndsAt:   NewTimestampValue("2024-03-15T06:00:00Z"),
+				SiteID:   types.StringValue(tt.siteID),
+				DeviceID: types.StringValue(tt.deviceID),
+			})
+
+			r := &MaintenanceWindowResource{client: NewClient("test-token", server.URL)}
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{Config: config, Plan: plan}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if got := resp.Diagnostics.WarningsCount() == 1; got != tt.wantWarning {
+				t.Fatalf("expected warning %v, got: %v", tt.wantWarning, resp.Diagnostics)
+			}
+			if tt.wantWarning && !strings.Contains(resp.Diagnostics.Warnings()[0].Detail(), "is at site site-1") {
+				t.Errorf("expected warning to name the device's site, got: %s", resp.Diagnostics.Warnings()[0].Detail())
+			}
+		})
+	}
+}
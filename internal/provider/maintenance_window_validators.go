@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultMaintenanceWindowMaxDuration is the longest maintenance window
+// allowed when the provider's maintenance_window_max_duration is unset.
+const defaultMaintenanceWindowMaxDuration = 30 * 24 * time.Hour
+
+var _ resource.ConfigValidator = maintenanceWindowOrderValidator{}
+
+// maintenanceWindowOrderValidator rejects windows that end before they start.
+type maintenanceWindowOrderValidator struct{}
+
+func (v maintenanceWindowOrderValidator) Description(ctx context.Context) string {
+	return "ends_at must be after starts_at"
+}
+
+func (v maintenanceWindowOrderValidator) MarkdownDescription(ctx context.Context) string {
+	return "`ends_at` must be after `starts_at`"
+}
+
+func (v maintenanceWindowOrderValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var startsAt, endsAt TimestampValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("starts_at"), &startsAt)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("ends_at"), &endsAt)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	duration, ok := maintenanceWindowDuration(startsAt, endsAt)
+	if ok && duration <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ends_at"),
+			"Invalid Maintenance Window",
+			fmt.Sprintf("ends_at (%s) must be after starts_at (%s).", endsAt.ValueString(), startsAt.ValueString()),
+		)
+	}
+}
+
+// maintenanceWindowDuration returns the length of a window. It reports false
+// when either time is unknown, null or invalid; invalid times are reported by
+// TimestampType.
+func maintenanceWindowDuration(startsAt, endsAt TimestampValue) (time.Duration, bool) {
+	if startsAt.IsNull() || startsAt.IsUnknown() || endsAt.IsNull() || endsAt.IsUnknown() {
+		return 0, false
+	}
+
+	start, err := startsAt.ValueTime()
+	if err != nil {
+		return 0, false
+	}
+	end, err := endsAt.ValueTime()
+	if err != nil {
+		return 0, false
+	}
+
+	return end.Sub(start), true
+}
+
+// maintenanceWindowMaxDuration returns the provider's
+// maintenance_window_max_duration, or the default.
+func maintenanceWindowMaxDuration(client *Client) time.Duration {
+	if client == nil || client.MaintenanceWindowMaxDuration == 0 {
+		return defaultMaintenanceWindowMaxDuration
+	}
+	return client.MaintenanceWindowMaxDuration
+}
+
+// validateMaintenanceWindowPlan checks a planned window against the
+// provider's maximum duration, and warns when device_id names a device at
+// another site than site_id. Both need the configured provider, so they run
+// when planning rather than as config validators.
+func validateMaintenanceWindowPlan(ctx context.Context, client *Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the window is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan MaintenanceWindowResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	maxDuration := maintenanceWindowMaxDuration(client)
+	if duration, ok := maintenanceWindowDuration(plan.StartsAt, plan.EndsAt); ok && duration > maxDuration {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ends_at"),
+			"Maintenance Window Too Long",
+			fmt.Sprintf("The window lasts %s, which is longer than the maximum of %s. Shorten it, or raise maintenance_window_max_duration in the provider configuration.", duration, maxDuration),
+		)
+		return
+	}
+
+	if client == nil || plan.SiteID.IsNull() || plan.SiteID.IsUnknown() || plan.DeviceID.IsNull() || plan.DeviceID.IsUnknown() {
+		return
+	}
+
+	device, err := client.GetDevice(plan.DeviceID.ValueString())
+	if err != nil {
+		// The lookup is only advisory, so plans keep working offline.
+		tflog.Debug(ctx, "Skipping maintenance window site check", map[string]any{
+			"device_id": plan.DeviceID.ValueString(),
+			"error":     err.Error(),
+		})
+		return
+	}
+
+	if device.SiteID != nil && *device.SiteID != plan.SiteID.ValueString() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("site_id"),
+			"Maintenance Window Site Conflict",
+			fmt.Sprintf("device_id %s is at site %s, not at site_id %s. A window with both set only covers the device while it is at site_id, so this window would not cover anything. Remove site_id, or set it to %s.", plan.DeviceID.ValueString(), *device.SiteID, plan.SiteID.ValueString(), *device.SiteID),
+		)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -12,6 +13,9 @@ import (
 )
 
 var _ resource.Resource = &OrganizationResource{}
+var _ resource.ResourceWithUpgradeState = &OrganizationResource{}
+var _ resource.ResourceWithValidateConfig = &OrganizationResource{}
+var _ resource.ResourceWithModifyPlan = &OrganizationResource{}
 
 // OrganizationResource manages organization settings.
 type OrganizationResource struct {
@@ -25,6 +29,9 @@ type OrganizationResourceModel struct {
 	Slug          types.String `tfsdk:"slug"`
 	UseSites      types.Bool   `tfsdk:"use_sites"`
 	SnmpCommunity types.String `tfsdk:"snmp_community"`
+
+	SnmpCommunityWO        types.String `tfsdk:"snmp_community_wo"`
+	SnmpCommunityWOVersion types.Int64  `tfsdk:"snmp_community_wo_version"`
 }
 
 // NewOrganizationResource creates a new organization resource.
@@ -38,6 +45,7 @@ func (r *OrganizationResource) Metadata(ctx context.Context, req resource.Metada
 
 func (r *OrganizationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages organization settings for the organization associated with the API token. There is exactly one organization per token, so this resource manages settings rather than creating or deleting organizations.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -65,10 +73,52 @@ func (r *OrganizationResource) Schema(ctx context.Context, req resource.SchemaRe
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"snmp_community_wo":         writeOnlyAttribute("snmp_community", "Default SNMP community string for devices. Can only be set by organization owners."),
+			"snmp_community_wo_version": writeOnlyVersionAttribute("snmp_community"),
 		},
 	}
 }
 
+func (r *OrganizationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	if r.client != nil {
+		r.client.SetOrganizationManaged()
+	}
+	resp.Diagnostics.Append(validateWriteOnlyConfig(ctx, req.Config, "snmp_community")...)
+}
+
+// ModifyPlan records the planned use_sites value with the client, so devices
+// planned after the organization are checked against the new setting rather
+// than the current one.
+func (r *OrganizationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if r.client == nil || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var useSites types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("use_sites"), &useSites)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan := UseSitesPlan{Known: !useSites.IsUnknown(), Value: useSites.ValueBool(), Changing: true}
+	if !req.State.Raw.IsNull() {
+		var current types.Bool
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("use_sites"), &current)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.Changing = !current.Equal(useSites)
+	}
+
+	r.client.SetPlannedUseSites(plan)
+}
+
+func (r *OrganizationResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r,
+		upgradeFromV0,
+	)
+}
+
 func (r *OrganizationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -108,6 +158,15 @@ func (r *OrganizationResource) Create(ctx context.Context, req resource.CreateRe
 		org.SnmpCommunity = data.SnmpCommunity.ValueString()
 	}
 
+	community, diags := writeOnlySecret(ctx, req.Config, nil, "snmp_community")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if community != nil {
+		org.SnmpCommunity = *community
+	}
+
 	updated, err := r.client.UpdateOrganization(org)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to update organization", err.Error())
@@ -119,6 +178,9 @@ func (r *OrganizationResource) Create(ctx context.Context, req resource.CreateRe
 	data.Slug = types.StringValue(updated.Slug)
 	data.UseSites = types.BoolValue(updated.UseSites)
 	data.SnmpCommunity = types.StringValue(updated.SnmpCommunity)
+	if !data.SnmpCommunityWOVersion.IsNull() {
+		data.SnmpCommunity = types.StringNull()
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -131,6 +193,7 @@ func (r *OrganizationResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	r.client.SetOrganizationManaged()
 	org, err := r.client.GetOrganization()
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to read organization", err.Error())
@@ -167,6 +230,15 @@ func (r *OrganizationResource) Update(ctx context.Context, req resource.UpdateRe
 		org.SnmpCommunity = data.SnmpCommunity.ValueString()
 	}
 
+	community, diags := writeOnlySecret(ctx, req.Config, &req.State, "snmp_community")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if community != nil {
+		org.SnmpCommunity = *community
+	}
+
 	updated, err := r.client.UpdateOrganization(org)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to update organization", err.Error())
@@ -178,6 +250,9 @@ func (r *OrganizationResource) Update(ctx context.Context, req resource.UpdateRe
 	data.Slug = types.StringValue(updated.Slug)
 	data.UseSites = types.BoolValue(updated.UseSites)
 	data.SnmpCommunity = types.StringValue(updated.SnmpCommunity)
+	if !data.SnmpCommunityWOVersion.IsNull() {
+		data.SnmpCommunity = types.StringNull()
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,6 +9,10 @@ import (
 	"sync"
 	"testing"
 
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -137,3 +142,69 @@ resource "towerops_organization" "settings" {
 }
 `, apiURL, useSites)
 }
+
+func TestOrganizationResource_ModifyPlan_recordsUseSites(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &fwresource.SchemaResponse{}
+	NewOrganizationResource().Schema(ctx, fwresource.SchemaRequest{}, schemaResp)
+
+	organization := func(useSites types.Bool) OrganizationResourceModel {
+		return OrganizationResourceModel{
+			ID:                     types.StringValue("org-123"),
+			Name:                   types.StringValue("Test ISP"),
+			Slug:                   types.StringValue("test-isp"),
+			UseSites:               useSites,
+			SnmpCommunity:          types.StringNull(),
+			SnmpCommunityWO:        types.StringNull(),
+			SnmpCommunityWOVersion: types.Int64Null(),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		current types.Bool
+		planned types.Bool
+		want    UseSitesPlan
+	}{
+		{"unchanged", types.BoolValue(true), types.BoolValue(true), UseSitesPlan{Known: true, Value: true}},
+		{"turned off", types.BoolValue(true), types.BoolValue(false), UseSitesPlan{Known: true, Value: false, Changing: true}},
+		{"unknown", types.BoolValue(true), types.BoolUnknown(), UseSitesPlan{Changing: true}},
+		{"first apply", types.BoolNull(), types.BoolValue(true), UseSitesPlan{Known: true, Value: true, Changing: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+			// A null current value stands for a resource not yet in state.
+			if !tt.current.IsNull() {
+				current := organization(tt.current)
+				if diags := state.Set(ctx, &current); diags.HasError() {
+					t.Fatalf("unexpected error building state: %v", diags)
+				}
+			}
+			plan := tfsdk.Plan{Schema: schemaResp.Schema}
+			planned := organization(tt.planned)
+			if diags := plan.Set(ctx, &planned); diags.HasError() {
+				t.Fatalf("unexpected error building plan: %v", diags)
+			}
+
+			client := &Client{}
+			r := &OrganizationResource{client: client}
+			resp := &fwresource.ModifyPlanResponse{Plan: plan}
+			r.ModifyPlan(ctx, fwresource.ModifyPlanRequest{
+				Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: plan.Raw},
+				Plan:   plan,
+				State:  state,
+			}, resp)
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			got := client.PlannedUseSites()
+			if got == nil || *got != tt.want {
+				t.Errorf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// errPollTimeout is returned by pollUntil when done is still false after the
+// timeout.
+var errPollTimeout = errors.New("timed out")
+
+// pollUntil calls refresh every interval until done reports true. It returns
+// errPollTimeout once timeout has passed, ctx.Err() if ctx is cancelled, or
+// the first error from refresh. done is checked before the first wait, so a
+// job that has already finished is not polled at all.
+func pollUntil(ctx context.Context, interval, timeout time.Duration, done func() bool, refresh func() error) error {
+	deadline := time.Now().Add(timeout)
+
+	for !done() {
+		if !time.Now().Before(deadline) {
+			return errPollTimeout
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+
+		if err := refresh(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPollUntil(t *testing.T) {
+	errRefresh := errors.New("refresh failed")
+
+	tests := []struct {
+		name        string
+		doneAfter   int
+		refreshErr  error
+		timeout     time.Duration
+		cancel      bool
+		wantErr     error
+		wantRefresh int
+	}{
+		{name: "already done", doneAfter: 0, timeout: time.Minute, wantRefresh: 0},
+		{name: "done after polling", doneAfter: 3, timeout: time.Minute, wantRefresh: 3},
+		{name: "refresh error", doneAfter: 3, refreshErr: errRefresh, timeout: time.Minute, wantErr: errRefresh, wantRefresh: 1},
+		{name: "timeout", doneAfter: -1, timeout: 0, wantErr: errPollTimeout, wantRefresh: 0},
+		{name: "cancelled", doneAfter: -1, timeout: time.Minute, cancel: true, wantErr: context.Canceled, wantRefresh: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancel {
+				cancel()
+			}
+
+			refreshes := 0
+			err := pollUntil(ctx, time.Millisecond, tt.timeout,
+				func() bool { return tt.doneAfter >= 0 && refreshes >= tt.doneAfter },
+				func() error {
+					refreshes++
+					return tt.refreshErr
+				},
+			)
+
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if refreshes != tt.wantRefresh {
+				t.Errorf("expected %d refreshes, got %d", tt.wantRefresh, refreshes)
+			}
+		})
+	}
+}
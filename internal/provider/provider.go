@@ -2,15 +2,32 @@ package provider
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 var _ provider.Provider = &ToweropsProvider{}
+var _ provider.ProviderWithEphemeralResources = &ToweropsProvider{}
+var _ provider.ProviderWithActions = &ToweropsProvider{}
+var _ provider.ProviderWithListResources = &ToweropsProvider{}
 
 // ToweropsProvider defines the provider implementation.
 type ToweropsProvider struct {
@@ -19,8 +36,28 @@ type ToweropsProvider struct {
 
 // ToweropsProviderModel describes the provider data model.
 type ToweropsProviderModel struct {
-	Token  types.String `tfsdk:"token"`
-	APIURL types.String `tfsdk:"api_url"`
+	Token                        types.String         `tfsdk:"token"`
+	APIURL                       types.String         `tfsdk:"api_url"`
+	ReadOnly                     types.Bool           `tfsdk:"read_only"`
+	SkipCredentialsValidation    types.Bool           `tfsdk:"skip_credentials_validation"`
+	DefaultLabels                types.Map            `tfsdk:"default_labels"`
+	DeletionProtection           types.Bool           `tfsdk:"deletion_protection"`
+	MaintenanceWindowMaxDuration types.String         `tfsdk:"maintenance_window_max_duration"`
+	DeviceDefaults               *DeviceDefaultsModel `tfsdk:"device_defaults"`
+}
+
+// DeviceDefaultsModel describes the provider device_defaults block.
+type DeviceDefaultsModel struct {
+	MonitoringEnabled   types.Bool   `tfsdk:"monitoring_enabled"`
+	SNMPEnabled         types.Bool   `tfsdk:"snmp_enabled"`
+	SNMPVersion         types.String `tfsdk:"snmp_version"`
+	SNMPPort            types.Int64  `tfsdk:"snmp_port"`
+	SNMPv3SecurityLevel types.String `tfsdk:"snmpv3_security_level"`
+	SNMPv3Username      types.String `tfsdk:"snmpv3_username"`
+	SNMPv3AuthProtocol  types.String `tfsdk:"snmpv3_auth_protocol"`
+	SNMPv3AuthPassword  types.String `tfsdk:"snmpv3_auth_password"`
+	SNMPv3PrivProtocol  types.String `tfsdk:"snmpv3_priv_protocol"`
+	SNMPv3PrivPassword  types.String `tfsdk:"snmpv3_priv_password"`
 }
 
 // New creates a new provider instance.
@@ -50,6 +87,91 @@ func (p *ToweropsProvider) Schema(ctx context.Context, req provider.SchemaReques
 				Description: "The base URL for the TowerOps API. Defaults to https://towerops.net.",
 				Optional:    true,
 			},
+			"read_only": schema.BoolAttribute{
+				Description: "When true, the provider refuses every create, update and delete call before it reaches the API. Reads, imports and data sources keep working, but the agent and API token ephemeral resources and the device rediscover and integration sync actions are refused too. Can also be set with the TOWEROPS_READ_ONLY environment variable. Defaults to false.",
+				Optional:    true,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Description: "Skip the request made during provider configuration that verifies the token and api_url. Useful for offline plans. Defaults to false.",
+				Optional:    true,
+			},
+			"default_labels": schema.MapAttribute{
+				Description: "Labels applied to every labelled resource managed by this provider. Labels set on a resource take precedence over these defaults.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Description: "Default for deletion_protection on towerops_site, towerops_device and towerops_agent resources that don't set it themselves. Defaults to false.",
+				Optional:    true,
+			},
+			"maintenance_window_max_duration": schema.StringAttribute{
+				Description: "The longest towerops_maintenance_window allowed, as a duration such as \"72h\". Longer windows are rejected when planning. Defaults to 720h (30 days).",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"device_defaults": schema.SingleNestedBlock{
+				Description: "Default settings applied to every towerops_device that doesn't set them itself. SNMPv3 defaults are only applied to devices whose effective snmp_version is \"3\".",
+				Attributes: map[string]schema.Attribute{
+					"monitoring_enabled": schema.BoolAttribute{
+						Description: "Default for monitoring_enabled. Falls back to true.",
+						Optional:    true,
+					},
+					"snmp_enabled": schema.BoolAttribute{
+						Description: "Default for snmp_enabled. Falls back to true.",
+						Optional:    true,
+					},
+					"snmp_version": schema.StringAttribute{
+						Description: "Default SNMP version (1, 2c, or 3). Falls back to 2c.",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(snmpVersions...),
+						},
+					},
+					"snmp_port": schema.Int64Attribute{
+						Description: "Default SNMP port, between 1 and 65535. Falls back to 161.",
+						Optional:    true,
+						Validators: []validator.Int64{
+							int64validator.Between(snmpPortMin, snmpPortMax),
+						},
+					},
+					"snmpv3_security_level": schema.StringAttribute{
+						Description: "Default SNMPv3 security level (noAuthNoPriv, authNoPriv, or authPriv).",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(snmpv3SecurityLevels...),
+						},
+					},
+					"snmpv3_username": schema.StringAttribute{
+						Description: "Default SNMPv3 username.",
+						Optional:    true,
+					},
+					"snmpv3_auth_protocol": schema.StringAttribute{
+						Description: "Default SNMPv3 authentication protocol (MD5, SHA, SHA-224, SHA-256, SHA-384, SHA-512).",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(snmpv3AuthProtocols...),
+						},
+					},
+					"snmpv3_auth_password": schema.StringAttribute{
+						Description: "Default SNMPv3 authentication password.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+					"snmpv3_priv_protocol": schema.StringAttribute{
+						Description: "Default SNMPv3 privacy protocol (DES, AES, AES-192, AES-256).",
+						Optional:    true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(snmpv3PrivProtocols...),
+						},
+					},
+					"snmpv3_priv_password": schema.StringAttribute{
+						Description: "Default SNMPv3 privacy password.",
+						Optional:    true,
+						Sensitive:   true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -78,10 +200,117 @@ func (p *ToweropsProvider) Configure(ctx context.Context, req provider.Configure
 		return
 	}
 
+	if config.ReadOnly.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("read_only"),
+			"Unknown TowerOps Read-Only Mode",
+			"The provider cannot determine whether to allow changes as there is an unknown configuration value for read_only.",
+		)
+		return
+	}
+
+	readOnly := config.ReadOnly.ValueBool()
+	if config.ReadOnly.IsNull() {
+		if v := os.Getenv("TOWEROPS_READ_ONLY"); v != "" {
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid TOWEROPS_READ_ONLY Value",
+					fmt.Sprintf("The TOWEROPS_READ_ONLY environment variable must be a boolean (true or false), got: %q", v),
+				)
+				return
+			}
+			readOnly = parsed
+		}
+	}
+
+	if config.DefaultLabels.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_labels"),
+			"Unknown TowerOps Default Labels",
+			"The provider cannot determine the default labels to apply as there is an unknown configuration value for default_labels.",
+		)
+		return
+	}
+
+	defaultLabels := make(map[string]string)
+	if !config.DefaultLabels.IsNull() {
+		resp.Diagnostics.Append(config.DefaultLabels.ElementsAs(ctx, &defaultLabels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	client := NewClient(config.Token.ValueString(), config.APIURL.ValueString())
+	client.ReadOnly = readOnly
+	client.DefaultLabels = defaultLabels
+	client.DeletionProtection = config.DeletionProtection.ValueBool()
+
+	if config.MaintenanceWindowMaxDuration.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("maintenance_window_max_duration"),
+			"Unknown Maintenance Window Maximum Duration",
+			"The provider cannot determine the maximum maintenance window duration as there is an unknown configuration value for maintenance_window_max_duration.",
+		)
+		return
+	}
+
+	if !config.MaintenanceWindowMaxDuration.IsNull() {
+		maxDuration, err := time.ParseDuration(config.MaintenanceWindowMaxDuration.ValueString())
+		if err != nil || maxDuration <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("maintenance_window_max_duration"),
+				"Invalid Maintenance Window Maximum Duration",
+				fmt.Sprintf("maintenance_window_max_duration must be a positive duration such as \"72h\", got: %q", config.MaintenanceWindowMaxDuration.ValueString()),
+			)
+			return
+		}
+		client.MaintenanceWindowMaxDuration = maxDuration
+	}
+
+	if config.DeviceDefaults != nil {
+		d := config.DeviceDefaults
+		for _, v := range []attr.Value{
+			d.MonitoringEnabled, d.SNMPEnabled, d.SNMPVersion, d.SNMPPort,
+			d.SNMPv3SecurityLevel, d.SNMPv3Username, d.SNMPv3AuthProtocol,
+			d.SNMPv3AuthPassword, d.SNMPv3PrivProtocol, d.SNMPv3PrivPassword,
+		} {
+			if v.IsUnknown() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("device_defaults"),
+					"Unknown TowerOps Device Defaults",
+					"The provider cannot determine the device defaults to apply as there is an unknown configuration value in device_defaults.",
+				)
+				return
+			}
+		}
+
+		client.DeviceDefaults = DeviceDefaults{
+			MonitoringEnabled:   d.MonitoringEnabled.ValueBoolPointer(),
+			SNMPEnabled:         d.SNMPEnabled.ValueBoolPointer(),
+			SNMPVersion:         d.SNMPVersion.ValueStringPointer(),
+			SNMPPort:            d.SNMPPort.ValueInt64Pointer(),
+			SNMPv3SecurityLevel: d.SNMPv3SecurityLevel.ValueStringPointer(),
+			SNMPv3Username:      d.SNMPv3Username.ValueStringPointer(),
+			SNMPv3AuthProtocol:  d.SNMPv3AuthProtocol.ValueStringPointer(),
+			SNMPv3AuthPassword:  d.SNMPv3AuthPassword.ValueStringPointer(),
+			SNMPv3PrivProtocol:  d.SNMPv3PrivProtocol.ValueStringPointer(),
+			SNMPv3PrivPassword:  d.SNMPv3PrivPassword.ValueStringPointer(),
+		}
+	}
+
+	if !config.SkipCredentialsValidation.ValueBool() {
+		resp.Diagnostics.Append(validateCredentials(client)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	resp.DataSourceData = client
 	resp.ResourceData = client
+	resp.EphemeralResourceData = client
+	resp.ActionData = client
+	resp.ListResourceData = client
 }
 
 func (p *ToweropsProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -98,5 +327,68 @@ func (p *ToweropsProvider) Resources(ctx context.Context) []func() resource.Reso
 }
 
 func (p *ToweropsProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewSiteDataSource,
+		NewSitesDataSource,
+	}
+}
+
+func (p *ToweropsProvider) EphemeralResources(ctx context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewAgentTokenEphemeralResource,
+		NewAPITokenEphemeralResource,
+	}
+}
+
+func (p *ToweropsProvider) ListResources(ctx context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewSiteListResource,
+		NewDeviceListResource,
+		NewScheduleListResource,
+		NewEscalationPolicyListResource,
+		NewAgentListResource,
+		NewIntegrationListResource,
+		NewMaintenanceWindowListResource,
+	}
+}
+
+func (p *ToweropsProvider) Actions(ctx context.Context) []func() action.Action {
+	return []func() action.Action{
+		NewDeviceRediscoverAction,
+		NewIntegrationSyncAction,
+	}
+}
+
+// validateCredentials makes a single lightweight request so that a bad token
+// or api_url is reported during configuration instead of by the first
+// resource that happens to call the API.
+func validateCredentials(client *Client) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	orgID, err := client.OrganizationID()
+	switch {
+	case err == nil && orgID != "":
+		return diags
+	case errors.Is(err, ErrUnauthorized):
+		diags.AddAttributeError(
+			path.Root("token"),
+			"Invalid TowerOps API Token",
+			"The TowerOps API rejected the configured token. Check that it is correct and has not expired or been revoked.\n\n"+err.Error(),
+		)
+	case errors.Is(err, ErrForbidden):
+		// The API accepted the token but won't show it the organization,
+		// as with scoped API tokens. Each resource call checks its own
+		// permissions.
+		return diags
+	default:
+		detail := fmt.Sprintf("The provider could not verify its credentials against %s. Check that api_url points at a TowerOps server, or set skip_credentials_validation = true for offline plans.", client.BaseURL)
+		if err != nil {
+			detail += "\n\n" + err.Error()
+		} else {
+			detail += "\n\nThe server responded without an organization."
+		}
+		diags.AddAttributeError(path.Root("api_url"), "Unable to Verify TowerOps API URL", detail)
+	}
+
+	return diags
 }
@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
@@ -26,7 +28,7 @@ func TestProvider_Schema(t *testing.T) {
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{}`))
+		w.Write([]byte(`{"data": {"id": "org-123", "name": "Test ISP"}}`))
 	}))
 	defer server.Close()
 
@@ -85,6 +87,292 @@ resource "towerops_site" "test" {
 	})
 }
 
+func TestProvider_ReadOnly(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected %s request to %s in read-only mode", r.Method, r.URL.Path)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(server.URL),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "towerops" {
+  token                       = "test-token"
+  api_url                     = "` + server.URL + `"
+  read_only                   = true
+  skip_credentials_validation = true
+}
+
+resource "towerops_site" "test" {
+  name = "Test"
+}
+`,
+				ExpectError: regexp.MustCompile(`read-only mode`),
+			},
+		},
+	})
+}
+
+func TestProvider_InvalidToken(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "invalid token"}`))
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(server.URL),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(server.URL) + `
+resource "towerops_site" "test" {
+  name = "Test"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid TowerOps API Token`),
+			},
+		},
+	})
+}
+
+func TestProvider_InvalidMaintenanceWindowMaxDuration(t *testing.T) {
+	t.Parallel()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(""),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "towerops" {
+  token                           = "test-token"
+  api_url                         = "http://localhost"
+  skip_credentials_validation     = true
+  maintenance_window_max_duration = "30 days"
+}
+
+resource "towerops_site" "test" {
+  name = "Test"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Maintenance Window Maximum Duration`),
+			},
+		},
+	})
+}
+
+func TestProvider_Configure_unknownReadOnly(t *testing.T) {
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error creating provider server: %v", err)
+	}
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: newDynamicValue(t, schemaResp.Provider.ValueType().(tftypes.Object), map[string]tftypes.Value{
+			"token":                       tfString("test-token"),
+			"api_url":                     tfString("http://localhost"),
+			"skip_credentials_validation": tftypes.NewValue(tftypes.Bool, true),
+			"read_only":                   tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue),
+		}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var errs []string
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			errs = append(errs, d.Summary)
+		}
+	}
+	if len(errs) != 1 || errs[0] != "Unknown TowerOps Read-Only Mode" {
+		t.Errorf("expected an unknown read_only error, got: %v", errs)
+	}
+}
+
+func TestValidateCredentials(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		body      string
+		wantError string
+	}{
+		{
+			name:   "valid",
+			status: http.StatusOK,
+			body:   `{"data": {"id": "org-123", "name": "Test ISP"}}`,
+		},
+		{
+			name:      "unauthorized",
+			status:    http.StatusUnauthorized,
+			body:      `{"error": "invalid token"}`,
+			wantError: "Invalid TowerOps API Token",
+		},
+		{
+			name:   "scoped token",
+			status: http.StatusForbidden,
+			body:   `{"error": "missing scope organization:read"}`,
+		},
+		{
+			name:      "wrong api_url",
+			status:    http.StatusNotFound,
+			body:      `<html>Not Found</html>`,
+			wantError: "Unable to Verify TowerOps API URL",
+		},
+		{
+			name:      "not a TowerOps server",
+			status:    http.StatusOK,
+			body:      `{}`,
+			wantError: "Unable to Verify TowerOps API URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/v1/organization" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			diags := validateCredentials(NewClient("test-token", server.URL))
+
+			if tt.wantError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				return
+			}
+
+			if !diags.HasError() {
+				t.Fatal("expected error, got none")
+			}
+			if summary := diags.Errors()[0].Summary(); summary != tt.wantError {
+				t.Errorf("expected %q, got %q", tt.wantError, summary)
+			}
+		})
+	}
+}
+
+func TestProvider_GetProviderSchema(t *testing.T) {
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error creating provider server: %v", err)
+	}
+
+	resp, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	for name := range resp.ResourceSchemas {
+		if name == "towerops_organization" {
+			continue
+		}
+		if _, ok := resp.ListResourceSchemas[name]; !ok {
+			t.Errorf("expected list resource for %s", name)
+		}
+	}
+
+	identityResp, err := server.GetResourceIdentitySchemas(context.Background(), &tfprotov6.GetResourceIdentitySchemasRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, d := range identityResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	for name := range resp.ListResourceSchemas {
+		if _, ok := identityResp.IdentitySchemas[name]; !ok {
+			t.Errorf("expected identity schema for %s", name)
+		}
+	}
+}
+
+// newConfiguredProviderServer returns a provider server configured against
+// apiURL, with attrs added to the provider configuration, along with its
+// schemas.
+func newConfiguredProviderServer(t *testing.T, apiURL string, attrs map[string]tftypes.Value) (tfprotov6.ProviderServer, *tfprotov6.GetProviderSchemaResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error creating provider server: %v", err)
+	}
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config := map[string]tftypes.Value{
+		"token":                       tfString("test-token"),
+		"api_url":                     tfString(apiURL),
+		"skip_credentials_validation": tftypes.NewValue(tftypes.Bool, true),
+	}
+	for name, value := range attrs {
+		config[name] = value
+	}
+
+	configureResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		Config: newDynamicValue(t, schemaResp.Provider.ValueType().(tftypes.Object), config),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, d := range configureResp.Diagnostics {
+		if d.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error configuring provider: %s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	return server, schemaResp
+}
+
+// newDynamicValue encodes an object of objectType with attrs set and every
+// other attribute null.
+func newDynamicValue(t *testing.T, objectType tftypes.Object, attrs map[string]tftypes.Value) *tfprotov6.DynamicValue {
+	t.Helper()
+
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, typ := range objectType.AttributeTypes {
+		values[name] = tftypes.NewValue(typ, nil)
+	}
+	for name, value := range attrs {
+		values[name] = value
+	}
+
+	value, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+	if err != nil {
+		t.Fatalf("unexpected error encoding value: %v", err)
+	}
+	return &value
+}
+
 func testAccProviderConfig(apiURL string) string {
 	return `
 provider "towerops" {
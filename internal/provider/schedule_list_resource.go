@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &ScheduleListResource{}
+var _ list.ListResourceWithConfigure = &ScheduleListResource{}
+
+// ScheduleListResource lists existing on-call schedules for terraform query.
+type ScheduleListResource struct {
+	client *Client
+}
+
+// ScheduleListResourceModel describes the list filters.
+type ScheduleListResourceModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+}
+
+// NewScheduleListResource creates a new schedule list resource.
+func NewScheduleListResource() list.ListResource {
+	return &ScheduleListResource{}
+}
+
+func (r *ScheduleListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schedule"
+}
+
+func (r *ScheduleListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing TowerOps on-call schedules.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Description: "Only list schedules whose name starts with this prefix.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *ScheduleListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *ScheduleListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config ScheduleListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	schedules, err := r.client.ListSchedules()
+	if err != nil {
+		stream.Results = listErrorStream("Failed to list on-call schedules", err.Error())
+		return
+	}
+
+	var matched []OnCallSchedule
+	for _, schedule := range schedules {
+		if !strings.HasPrefix(schedule.Name, config.NamePrefix.ValueString()) {
+			continue
+		}
+		matched = append(matched, schedule)
+	}
+
+	stream.Results = streamListResults(ctx, r.client, req, matched,
+		func(schedule OnCallSchedule) string { return schedule.ID },
+		func(schedule OnCallSchedule, result *list.ListResult) {
+			result.DisplayName = schedule.Name
+			if !req.IncludeResource {
+				return
+			}
+
+			data := ScheduleResourceModel{
+				ID:         types.StringValue(schedule.ID),
+				Name:       types.StringValue(schedule.Name),
+				Timezone:   NewTimezoneValue(schedule.Timezone),
+				InsertedAt: NewTimestampValue(schedule.InsertedAt),
+			}
+			if schedule.Description != nil {
+				data.Description = types.StringValue(*schedule.Description)
+			}
+
+			var d diag.Diagnostics
+			data.Labels, data.LabelsAll, d = labelsForImport(ctx, r.client, schedule.Labels)
+			result.Diagnostics.Append(d...)
+
+			result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+		},
+	)
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
@@ -14,7 +13,10 @@ import (
 )
 
 var _ resource.Resource = &ScheduleResource{}
+var _ resource.ResourceWithUpgradeState = &ScheduleResource{}
 var _ resource.ResourceWithImportState = &ScheduleResource{}
+var _ resource.ResourceWithIdentity = &ScheduleResource{}
+var _ resource.ResourceWithModifyPlan = &ScheduleResource{}
 
 // ScheduleResource defines the resource implementation.
 type ScheduleResource struct {
@@ -23,11 +25,13 @@ type ScheduleResource struct {
 
 // ScheduleResourceModel describes the resource data model.
 type ScheduleResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	Description types.String `tfsdk:"description"`
-	Timezone    types.String `tfsdk:"timezone"`
-	InsertedAt  types.String `tfsdk:"inserted_at"`
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Timezone    TimezoneValue  `tfsdk:"timezone"`
+	Labels      types.Map      `tfsdk:"labels"`
+	LabelsAll   types.Map      `tfsdk:"labels_all"`
+	InsertedAt  TimestampValue `tfsdk:"inserted_at"`
 }
 
 // NewScheduleResource creates a new schedule resource.
@@ -39,8 +43,13 @@ func (r *ScheduleResource) Metadata(ctx context.Context, req resource.MetadataRe
 	resp.TypeName = req.ProviderTypeName + "_schedule"
 }
 
+func (r *ScheduleResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema()
+}
+
 func (r *ScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages a TowerOps on-call schedule.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -59,10 +68,14 @@ func (r *ScheduleResource) Schema(ctx context.Context, req resource.SchemaReques
 				Optional:    true,
 			},
 			"timezone": schema.StringAttribute{
-				Description: "The timezone for the schedule (e.g. America/Chicago).",
+				CustomType:  TimezoneType{},
+				Description: "The IANA time zone for the schedule (e.g. America/Chicago). Deprecated aliases such as US/Central are accepted with a warning and sent to the API as the zone they link to.",
 				Required:    true,
 			},
+			"labels":     labelsAttribute(),
+			"labels_all": labelsAllAttribute(),
 			"inserted_at": schema.StringAttribute{
+				CustomType:  TimestampType{},
 				Description: "The timestamp when the schedule was created.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
@@ -73,6 +86,12 @@ func (r *ScheduleResource) Schema(ctx context.Context, req resource.SchemaReques
 	}
 }
 
+func (r *ScheduleResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r,
+		upgradeFromV0,
+	)
+}
+
 func (r *ScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -90,6 +109,10 @@ func (r *ScheduleResource) Configure(ctx context.Context, req resource.Configure
 	r.client = client
 }
 
+func (r *ScheduleResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanLabels(ctx, r.client, req, resp)
+}
+
 func (r *ScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data ScheduleResourceModel
 
@@ -100,7 +123,7 @@ func (r *ScheduleResource) Create(ctx context.Context, req resource.CreateReques
 
 	schedule := OnCallSchedule{
 		Name:     data.Name.ValueString(),
-		Timezone: data.Timezone.ValueString(),
+		Timezone: data.Timezone.Canonical(),
 	}
 
 	if !data.Description.IsNull() {
@@ -108,6 +131,13 @@ func (r *ScheduleResource) Create(ctx context.Context, req resource.CreateReques
 		schedule.Description = &desc
 	}
 
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	schedule.Labels = labels
+
 	created, err := r.client.CreateSchedule(schedule)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create schedule", err.Error())
@@ -115,13 +145,15 @@ func (r *ScheduleResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	data.ID = types.StringValue(created.ID)
-	data.InsertedAt = types.StringValue(created.InsertedAt)
+	data.InsertedAt = NewTimestampValue(created.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, created.Labels)...)
 
 	if created.Description != nil {
 		data.Description = types.StringValue(*created.Description)
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *ScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -143,8 +175,9 @@ func (r *ScheduleResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	data.Name = types.StringValue(schedule.Name)
-	data.Timezone = types.StringValue(schedule.Timezone)
-	data.InsertedAt = types.StringValue(schedule.InsertedAt)
+	data.Timezone = NewTimezoneValue(schedule.Timezone)
+	data.InsertedAt = NewTimestampValue(schedule.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, schedule.Labels)...)
 
 	if schedule.Description != nil {
 		data.Description = types.StringValue(*schedule.Description)
@@ -153,6 +186,7 @@ func (r *ScheduleResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *ScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -165,7 +199,7 @@ func (r *ScheduleResource) Update(ctx context.Context, req resource.UpdateReques
 
 	schedule := OnCallSchedule{
 		Name:     data.Name.ValueString(),
-		Timezone: data.Timezone.ValueString(),
+		Timezone: data.Timezone.Canonical(),
 	}
 
 	if !data.Description.IsNull() {
@@ -173,22 +207,17 @@ func (r *ScheduleResource) Update(ctx context.Context, req resource.UpdateReques
 		schedule.Description = &desc
 	}
 
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	schedule.Labels = labels
+
 	updated, err := r.client.UpdateSchedule(data.ID.ValueString(), schedule)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			created, createErr := r.client.CreateSchedule(schedule)
-			if createErr != nil {
-				resp.Diagnostics.AddError("Failed to create schedule (after 404 on update)", createErr.Error())
-				return
-			}
-			data.ID = types.StringValue(created.ID)
-			data.InsertedAt = types.StringValue(created.InsertedAt)
-			data.Name = types.StringValue(created.Name)
-			data.Timezone = types.StringValue(created.Timezone)
-			if created.Description != nil {
-				data.Description = types.StringValue(*created.Description)
-			}
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.Append(deletedDuringUpdate("schedule", data.ID.ValueString()))
 			return
 		}
 		resp.Diagnostics.AddError("Failed to update schedule", err.Error())
@@ -196,13 +225,16 @@ func (r *ScheduleResource) Update(ctx context.Context, req resource.UpdateReques
 	}
 
 	data.Name = types.StringValue(updated.Name)
-	data.Timezone = types.StringValue(updated.Timezone)
+	data.Timezone = NewTimezoneValue(updated.Timezone)
 
 	if updated.Description != nil {
 		data.Description = types.StringValue(*updated.Description)
 	}
 
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, updated.Labels)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *ScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -221,5 +253,14 @@ func (r *ScheduleResource) Delete(ctx context.Context, req resource.DeleteReques
 }
 
 func (r *ScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if key, ok := parseImportKey(req.ID); ok {
+		id, err := resolveScheduleImportKey(r.client, key)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot Import Schedule", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	importStateWithIdentity(ctx, r.client, req, resp)
 }
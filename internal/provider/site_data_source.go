@@ -0,0 +1,282 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SiteDataSource{}
+var _ datasource.DataSourceWithConfigure = &SiteDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &SiteDataSource{}
+
+// earthRadiusKm is the mean radius of the Earth, used for distances between
+// sites.
+const earthRadiusKm = 6371.0088
+
+// SiteDataSource looks up an existing site.
+type SiteDataSource struct {
+	client *Client
+}
+
+// SiteDataSourceModel describes the data source data model.
+type SiteDataSourceModel struct {
+	ID            types.String             `tfsdk:"id"`
+	Name          types.String             `tfsdk:"name"`
+	Near          *SiteDataSourceNearModel `tfsdk:"near"`
+	Location      types.String             `tfsdk:"location"`
+	Address       types.String             `tfsdk:"address"`
+	Latitude      types.Float64            `tfsdk:"latitude"`
+	Longitude     types.Float64            `tfsdk:"longitude"`
+	SNMPCommunity types.String             `tfsdk:"snmp_community"`
+	Labels        types.Map                `tfsdk:"labels"`
+	InsertedAt    TimestampValue           `tfsdk:"inserted_at"`
+}
+
+// SiteDataSourceNearModel describes the near lookup.
+type SiteDataSourceNearModel struct {
+	Latitude  types.Float64 `tfsdk:"latitude"`
+	Longitude types.Float64 `tfsdk:"longitude"`
+	RadiusKm  types.Float64 `tfsdk:"radius_km"`
+}
+
+// NewSiteDataSource creates a new site data source.
+func NewSiteDataSource() datasource.DataSource {
+	return &SiteDataSource{}
+}
+
+func (d *SiteDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site"
+}
+
+func (d *SiteDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an existing TowerOps site by ID, by exact name, or as the site nearest to a point. Exactly one of id, name and near must be set.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the site to look up.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The exact name of the site to look up. The lookup fails if more than one site has this name.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"near": schema.SingleNestedAttribute{
+				Description: "Look up the site nearest to a point, among sites with coordinates within radius_km of it.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"latitude": schema.Float64Attribute{
+						Description: "The latitude of the point (-90 to 90).",
+						Required:    true,
+						Validators:  []validator.Float64{float64validator.Between(-90, 90)},
+					},
+					"longitude": schema.Float64Attribute{
+						Description: "The longitude of the point (-180 to 180).",
+						Required:    true,
+						Validators:  []validator.Float64{float64validator.Between(-180, 180)},
+					},
+					"radius_km": schema.Float64Attribute{
+						Description: "The search radius in kilometers.",
+						Required:    true,
+						Validators:  []validator.Float64{float64validator.AtLeast(0)},
+					},
+				},
+			},
+			"location": schema.StringAttribute{
+				Description: "A short description of the physical location.",
+				Computed:    true,
+			},
+			"address": schema.StringAttribute{
+				Description: "The street address of the site.",
+				Computed:    true,
+			},
+			"latitude": schema.Float64Attribute{
+				Description: "The latitude of the site.",
+				Computed:    true,
+			},
+			"longitude": schema.Float64Attribute{
+				Description: "The longitude of the site.",
+				Computed:    true,
+			},
+			"snmp_community": schema.StringAttribute{
+				Description: "The default SNMP community string for devices at this site.",
+				Computed:    true,
+				Sensitive:   true,
+			},
+			"labels": schema.MapAttribute{
+				Description: "The labels on the site.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"inserted_at": schema.StringAttribute{
+				CustomType:  TimestampType{},
+				Description: "The timestamp when the site was created.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *SiteDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("name"),
+			path.MatchRoot("near"),
+		),
+	}
+}
+
+func (d *SiteDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SiteDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SiteDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var site *Site
+	var err error
+	switch {
+	case !data.ID.IsNull():
+		site, err = d.client.GetSite(data.ID.ValueString())
+		if errors.Is(err, ErrNotFound) {
+			err = fmt.Errorf("no site has ID %q", data.ID.ValueString())
+		}
+	case !data.Name.IsNull():
+		site, err = d.findSiteByName(data.Name.ValueString())
+	default:
+		site, err = d.findNearestSite(data.Near.Latitude.ValueFloat64(), data.Near.Longitude.ValueFloat64(), data.Near.RadiusKm.ValueFloat64())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to read site", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(site.ID)
+	data.Name = types.StringValue(site.Name)
+	data.Location = types.StringPointerValue(site.Location)
+	data.Address = types.StringPointerValue(site.Address)
+	data.Latitude = types.Float64PointerValue(site.Latitude)
+	data.Longitude = types.Float64PointerValue(site.Longitude)
+	data.SNMPCommunity = types.StringPointerValue(site.SNMPCommunity)
+	data.InsertedAt = NewTimestampValue(site.InsertedAt)
+
+	labels, diags := siteLabelsValue(ctx, site.Labels)
+	resp.Diagnostics.Append(diags...)
+	data.Labels = labels
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// siteLabelsValue converts site labels from the API to a map value, which is
+// empty rather than null when the site has no labels.
+func siteLabelsValue(ctx context.Context, labels map[string]string) (types.Map, diag.Diagnostics) {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	return types.MapValueFrom(ctx, types.StringType, labels)
+}
+
+// findSiteByName returns the only site called name.
+func (d *SiteDataSource) findSiteByName(name string) (*Site, error) {
+	sites, err := d.client.ListSites()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Site
+	for _, site := range sites {
+		if site.Name == name {
+			matches = append(matches, site)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no site is named %q", name)
+	case 1:
+		return &matches[0], nil
+	}
+
+	ids := make([]string, len(matches))
+	for i, site := range matches {
+		ids[i] = site.ID
+	}
+	return nil, fmt.Errorf("%d sites are named %q (%s); look the site up by id instead", len(matches), name, strings.Join(ids, ", "))
+}
+
+// findNearestSite returns the site closest to the point, among sites within
+// radiusKm of it.
+func (d *SiteDataSource) findNearestSite(latitude, longitude, radiusKm float64) (*Site, error) {
+	sites, err := d.client.ListSites()
+	if err != nil {
+		return nil, err
+	}
+
+	site := nearestSite(sites, latitude, longitude, radiusKm)
+	if site == nil {
+		return nil, fmt.Errorf("no site with coordinates is within %g km of %g, %g", radiusKm, latitude, longitude)
+	}
+	return site, nil
+}
+
+// nearestSite returns the site closest to the point, or nil when no site with
+// coordinates is within radiusKm. Ties go to the site listed first.
+func nearestSite(sites []Site, latitude, longitude, radiusKm float64) *Site {
+	var nearest *Site
+	nearestKm := radiusKm
+	for i, site := range sites {
+		if site.Latitude == nil || site.Longitude == nil {
+			continue
+		}
+
+		km := distanceKm(latitude, longitude, *site.Latitude, *site.Longitude)
+		if km < nearestKm || nearest == nil && km == nearestKm {
+			nearest, nearestKm = &sites[i], km
+		}
+	}
+	return nearest
+}
+
+// distanceKm returns the great-circle distance between two points, using the
+// haversine formula.
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	dLat := toRadians(lat2 - lat1)
+	dLon := toRadians(lon2 - lon1)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func float64Ptr(f float64) *float64 { return &f }
+
+// newSiteDataSourceServer serves a fixed set of sites: two named "Tower B",
+// and two without coordinates.
+func newSiteDataSourceServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	sites := []Site{
+		{ID: "site-1", Name: "Tower A", Latitude: float64Ptr(41.8781), Longitude: float64Ptr(-87.6298), SNMPCommunity: strPtr("tower-a"), Labels: map[string]string{"region": "north"}, InsertedAt: "2024-01-01T00:00:00Z"},
+		{ID: "site-2", Name: "Tower B", Latitude: float64Ptr(41.9742), Longitude: float64Ptr(-87.9073), InsertedAt: "2024-01-02T00:00:00Z"},
+		{ID: "site-3", Name: "Tower B", InsertedAt: "2024-01-03T00:00:00Z"},
+		{ID: "site-4", Name: "Office", Location: strPtr("Downtown"), InsertedAt: "2024-01-04T00:00:00Z"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if r.URL.Path == "/api/v1/sites" {
+			json.NewEncoder(w).Encode(map[string]interface{}{"data": sites})
+			return
+		}
+		for _, site := range sites {
+			if r.URL.Path == "/api/v1/sites/"+site.ID {
+				json.NewEncoder(w).Encode(site)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// readSiteDataSource runs the towerops_site data source against the server
+// with the given lookup attributes.
+func readSiteDataSource(t *testing.T, serverURL string, config SiteDataSourceModel) (SiteDataSourceModel, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &datasource.SchemaResponse{}
+	NewSiteDataSource().Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	config.Labels = types.MapNull(types.StringType)
+	configState := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := configState.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("unexpected error building config: %v", diags)
+	}
+
+	d := &SiteDataSource{client: NewClient("test-token", serverURL)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: configState.Raw}}, resp)
+
+	var data SiteDataSourceModel
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(resp.State.Get(ctx, &data)...)
+	}
+	return data, resp.Diagnostics
+}
+
+func TestSiteDataSource_Read(t *testing.T) {
+	server := newSiteDataSourceServer(t)
+
+	tests := []struct {
+		name    string
+		config  SiteDataSourceModel
+		wantID  string
+		wantErr string
+	}{
+		{
+			name:   "by id",
+			config: SiteDataSourceModel{ID: types.StringValue("site-4")},
+			wantID: "site-4",
+		},
+		{
+			name:    "unknown id",
+			config:  SiteDataSourceModel{ID: types.StringValue("site-9")},
+			wantErr: `no site has ID "site-9"`,
+		},
+		{
+			name:   "by name",
+			config: SiteDataSourceModel{Name: types.StringValue("Tower A")},
+			wantID: "site-1",
+		},
+		{
+			name:    "name not found",
+			config:  SiteDataSourceModel{Name: types.StringValue("tower a")},
+			wantErr: `no site is named "tower a"`,
+		},
+		{
+			name:    "duplicate name",
+			config:  SiteDataSourceModel{Name: types.StringValue("Tower B")},
+			wantErr: "2 sites are named \"Tower B\" (site-2, site-3)",
+		},
+		{
+			name: "nearest",
+			config: SiteDataSourceModel{Near: &SiteDataSourceNearModel{
+				Latitude:  types.Float64Value(41.95),
+				Longitude: types.Float64Value(-87.85),
+				RadiusKm:  types.Float64Value(50),
+			}},
+			wantID: "site-2",
+		},
+		{
+			name: "nothing within radius",
+			config: SiteDataSourceModel{Near: &SiteDataSourceNearModel{
+				Latitude:  types.Float64Value(40.7128),
+				Longitude: types.Float64Value(-74.0060),
+				RadiusKm:  types.Float64Value(100),
+			}},
+			wantErr: "no site with coordinates is within 100 km",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, diags := readSiteDataSource(t, server.URL, tt.config)
+
+			if tt.wantErr != "" {
+				if !diags.HasError() {
+					t.Fatalf("expected error %q", tt.wantErr)
+				}
+				if detail := diags.Errors()[0].Detail(); !strings.Contains(detail, tt.wantErr) {
+					t.Errorf("expected error %q, got: %s", tt.wantErr, detail)
+				}
+				return
+			}
+
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if data.ID.ValueString() != tt.wantID {
+				t.Errorf("expected site %s, got %s", tt.wantID, data.ID.ValueString())
+			}
+		})
+	}
+}
+
+func TestSiteDataSource_Read_fields(t *testing.T) {
+	server := newSiteDataSourceServer(t)
+
+	data, diags := readSiteDataSource(t, server.URL, SiteDataSourceModel{ID: types.StringValue("site-1")})
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if data.Name.ValueString() != "Tower A" {
+		t.Errorf("expected name Tower A, got %s", data.Name)
+	}
+	if data.Latitude.ValueFloat64() != 41.8781 || data.Longitude.ValueFloat64() != -87.6298 {
+		t.Errorf("unexpected coordinates %s, %s", data.Latitude, data.Longitude)
+	}
+	if data.SNMPCommunity.ValueString() != "tower-a" {
+		t.Errorf("expected snmp_community tower-a, got %s", data.SNMPCommunity)
+	}
+	if !data.Location.IsNull() || !data.Address.IsNull() {
+		t.Errorf("expected null location and address, got %s and %s", data.Location, data.Address)
+	}
+	if data.Labels.Elements()["region"] != types.StringValue("north") {
+		t.Errorf("expected region label, got %s", data.Labels)
+	}
+	if data.InsertedAt.ValueString() != "2024-01-01T00:00:00Z" {
+		t.Errorf("expected inserted_at 2024-01-01T00:00:00Z, got %s", data.InsertedAt)
+	}
+}
+
+func TestNearestSite(t *testing.T) {
+	sites := []Site{
+		{ID: "no-coordinates"},
+		{ID: "a", Latitude: float64Ptr(0), Longitude: float64Ptr(1)},
+		{ID: "b", Latitude: float64Ptr(0), Longitude: float64Ptr(-1)},
+		{ID: "c", Latitude: float64Ptr(0), Longitude: float64Ptr(0.5)},
+	}
+
+	if site := nearestSite(sites, 0, 0, 100); site == nil || site.ID != "c" {
+		t.Errorf("expected c, got %+v", site)
+	}
+	// a and b are equally far from the origin once c is out of the picture.
+	if site := nearestSite(sites[:3], 0, 0, 200); site == nil || site.ID != "a" {
+		t.Errorf("expected the first of equally near sites, got %+v", site)
+	}
+	if site := nearestSite(sites, 0, 0, 50); site != nil {
+		t.Errorf("expected no site within 50 km, got %+v", site)
+	}
+}
+
+func TestDistanceKm(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lon1, lat2, lon2 float64
+		want                   float64
+	}{
+		{"same point", 41.8781, -87.6298, 41.8781, -87.6298, 0},
+		{"one degree of longitude at the equator", 0, 0, 0, 1, 111.195},
+		{"across the antimeridian", 0, 179.5, 0, -179.5, 111.195},
+		{"Chicago to New York", 41.8781, -87.6298, 40.7128, -74.0060, 1144.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := distanceKm(tt.lat1, tt.lon1, tt.lat2, tt.lon2)
+			if math.Abs(got-tt.want) > 0.5 {
+				t.Errorf("expected %.1f km, got %.1f km", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAccSiteDataSource_byName(t *testing.T) {
+	server := newSiteDataSourceServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(server.URL),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteDataSourceConfig(server.URL, `name = "Tower A"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.towerops_site.test", "id", "site-1"),
+					resource.TestCheckResourceAttr("data.towerops_site.test", "latitude", "41.8781"),
+					resource.TestCheckResourceAttr("data.towerops_site.test", "labels.region", "north"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSiteDataSource_near(t *testing.T) {
+	server := newSiteDataSourceServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(server.URL),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteDataSourceConfig(server.URL, `near = {
+    latitude  = 41.88
+    longitude = -87.63
+    radius_km = 5
+  }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.towerops_site.test", "id", "site-1"),
+					resource.TestCheckResourceAttr("data.towerops_site.test", "name", "Tower A"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSiteDataSourceConfig(apiURL, lookup string) string {
+	return fmt.Sprintf(`
+provider "towerops" {
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
+}
+
+data "towerops_site" "test" {
+  %s
+}
+`, apiURL, lookup)
+}
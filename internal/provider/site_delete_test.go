@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func deleteSite(t *testing.T, client *Client, forceDestroy bool) *resource.DeleteResponse {
+	t.Helper()
+	ctx := context.Background()
+
+	r := &SiteResource{client: client}
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, &SiteResourceModel{
+		ID:           types.StringValue("site-123"),
+		Name:         types.StringValue("Main Office"),
+		Labels:       types.MapNull(types.StringType),
+		LabelsAll:    types.MapNull(types.StringType),
+		ForceDestroy: types.BoolValue(forceDestroy),
+	}); diags.HasError() {
+		t.Fatalf("unexpected error building state: %v", diags)
+	}
+
+	resp := &resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, resp)
+	return resp
+}
+
+func TestSiteResource_Delete_forceDestroy(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/devices":
+			if got := r.URL.Query().Get("site_id"); got != "site-123" {
+				t.Errorf("expected site_id=site-123, got %q", got)
+			}
+			w.Write([]byte(`{"data": [
+				{"id": "device-1", "site_id": "site-123", "name": "Core Router", "ip_address": "10.0.0.1"},
+				{"id": "device-2", "site_id": "site-123", "ip_address": "10.0.0.2"}
+			]}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/maintenance_windows":
+			w.Write([]byte(`{"data": [
+				{"id": "window-site", "name": "Site work", "starts_at": "2026-03-02T02:00:00Z", "ends_at": "2026-03-02T06:00:00Z", "site_id": "site-123"},
+				{"id": "window-device", "name": "Router work", "starts_at": "2026-03-02T02:00:00Z", "ends_at": "2026-03-02T06:00:00Z", "device_id": "device-2"},
+				{"id": "window-other", "name": "Elsewhere", "starts_at": "2026-03-02T02:00:00Z", "ends_at": "2026-03-02T06:00:00Z", "site_id": "site-999"}
+			]}`))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resp := deleteSite(t, NewClient("test-token", server.URL), true)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	expected := []string{
+		"/api/v1/maintenance_windows/window-site",
+		"/api/v1/maintenance_windows/window-device",
+		"/api/v1/devices/device-1",
+		"/api/v1/devices/device-2",
+		"/api/v1/sites/site-123",
+	}
+	if strings.Join(deleted, " ") != strings.Join(expected, " ") {
+		t.Errorf("expected deletes %v, got %v", expected, deleted)
+	}
+}
+
+func TestSiteResource_Delete_blockedByDevices(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/sites/site-123":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			w.Write([]byte(`{"error": "site has devices"}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/devices":
+			w.Write([]byte(`{"data": [
+				{"id": "device-1", "site_id": "site-123", "name": "Core Router", "ip_address": "10.0.0.1"},
+				{"id": "device-2", "site_id": "site-123", "ip_address": "10.0.0.2"}
+			]}`))
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resp := deleteSite(t, NewClient("test-token", server.URL), false)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected error for a site with devices")
+	}
+
+	err := resp.Diagnostics.Errors()[0]
+	if err.Summary() != "Site Has Devices" {
+		t.Errorf("expected Site Has Devices, got %q", err.Summary())
+	}
+	for _, want := range []string{"2 devices", "Core Router (device-1)", "10.0.0.2 (device-2)", "force_destroy = true"} {
+		if !strings.Contains(err.Detail(), want) {
+			t.Errorf("expected detail to contain %q, got: %s", want, err.Detail())
+		}
+	}
+}
+
+func TestDescribeDevices(t *testing.T) {
+	name := "Core Router"
+	if got := describeDevices([]Device{{ID: "device-1", Name: &name, IPAddress: "10.0.0.1"}}); got != "1 device: Core Router (device-1)" {
+		t.Errorf("unexpected description: %q", got)
+	}
+
+	devices := make([]Device, 12)
+	for i := range devices {
+		devices[i] = Device{ID: "device", IPAddress: "10.0.0.1"}
+	}
+	if got := describeDevices(devices); !strings.HasPrefix(got, "12 devices: ") || !strings.HasSuffix(got, ", and 2 more") {
+		t.Errorf("unexpected description: %q", got)
+	}
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ list.ListResource = &SiteListResource{}
+var _ list.ListResourceWithConfigure = &SiteListResource{}
+
+// SiteListResource lists existing sites for terraform query.
+type SiteListResource struct {
+	client *Client
+}
+
+// SiteListResourceModel describes the list filters.
+type SiteListResourceModel struct {
+	NamePrefix types.String `tfsdk:"name_prefix"`
+}
+
+// NewSiteListResource creates a new site list resource.
+func NewSiteListResource() list.ListResource {
+	return &SiteListResource{}
+}
+
+func (r *SiteListResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_site"
+}
+
+func (r *SiteListResource) ListResourceConfigSchema(ctx context.Context, req list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing TowerOps sites.",
+		Attributes: map[string]schema.Attribute{
+			"name_prefix": schema.StringAttribute{
+				Description: "Only list sites whose name starts with this prefix.",
+				Optional:    true,
+			},
+		},
+	}
+}
+
+func (r *SiteListResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *SiteListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	var config SiteListResourceModel
+
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	sites, err := r.client.ListSites()
+	if err != nil {
+		stream.Results = listErrorStream("Failed to list sites", err.Error())
+		return
+	}
+
+	var matched []Site
+	for _, site := range sites {
+		if strings.HasPrefix(site.Name, config.NamePrefix.ValueString()) {
+			matched = append(matched, site)
+		}
+	}
+
+	stream.Results = streamListResults(ctx, r.client, req, matched,
+		func(site Site) string { return site.ID },
+		func(site Site, result *list.ListResult) {
+			result.DisplayName = site.Name
+			if !req.IncludeResource {
+				return
+			}
+
+			data := SiteResourceModel{
+				ID:         types.StringValue(site.ID),
+				Name:       types.StringValue(site.Name),
+				InsertedAt: NewTimestampValue(site.InsertedAt),
+			}
+			setSiteOptionalFields(&data, &site)
+
+			var d diag.Diagnostics
+			data.Labels, data.LabelsAll, d = labelsForImport(ctx, r.client, site.Labels)
+			result.Diagnostics.Append(d...)
+
+			result.Diagnostics.Append(result.Resource.Set(ctx, &data)...)
+		},
+	)
+}
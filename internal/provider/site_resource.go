@@ -4,17 +4,24 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &SiteResource{}
+var _ resource.ResourceWithUpgradeState = &SiteResource{}
 var _ resource.ResourceWithImportState = &SiteResource{}
+var _ resource.ResourceWithIdentity = &SiteResource{}
+var _ resource.ResourceWithModifyPlan = &SiteResource{}
+var _ resource.ResourceWithValidateConfig = &SiteResource{}
 
 // SiteResource defines the resource implementation.
 type SiteResource struct {
@@ -23,14 +30,21 @@ type SiteResource struct {
 
 // SiteResourceModel describes the resource data model.
 type SiteResourceModel struct {
-	ID            types.String  `tfsdk:"id"`
-	Name          types.String  `tfsdk:"name"`
-	Location      types.String  `tfsdk:"location"`
-	Address       types.String  `tfsdk:"address"`
-	Latitude      types.Float64 `tfsdk:"latitude"`
-	Longitude     types.Float64 `tfsdk:"longitude"`
-	SNMPCommunity types.String  `tfsdk:"snmp_community"`
-	InsertedAt    types.String  `tfsdk:"inserted_at"`
+	ID                 types.String   `tfsdk:"id"`
+	Name               types.String   `tfsdk:"name"`
+	Location           types.String   `tfsdk:"location"`
+	Address            types.String   `tfsdk:"address"`
+	Latitude           types.Float64  `tfsdk:"latitude"`
+	Longitude          types.Float64  `tfsdk:"longitude"`
+	SNMPCommunity      types.String   `tfsdk:"snmp_community"`
+	Labels             types.Map      `tfsdk:"labels"`
+	LabelsAll          types.Map      `tfsdk:"labels_all"`
+	InsertedAt         TimestampValue `tfsdk:"inserted_at"`
+	DeletionProtection types.Bool     `tfsdk:"deletion_protection"`
+	ForceDestroy       types.Bool     `tfsdk:"force_destroy"`
+
+	SNMPCommunityWO        types.String `tfsdk:"snmp_community_wo"`
+	SNMPCommunityWOVersion types.Int64  `tfsdk:"snmp_community_wo_version"`
 }
 
 // NewSiteResource creates a new site resource.
@@ -42,8 +56,13 @@ func (r *SiteResource) Metadata(ctx context.Context, req resource.MetadataReques
 	resp.TypeName = req.ProviderTypeName + "_site"
 }
 
+func (r *SiteResource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = resourceIdentitySchema()
+}
+
 func (r *SiteResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:     1,
 		Description: "Manages a TowerOps site. Sites represent physical locations that contain devices.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -78,7 +97,19 @@ func (r *SiteResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"snmp_community_wo":         writeOnlyAttribute("snmp_community", "The default SNMP community string for devices at this site."),
+			"snmp_community_wo_version": writeOnlyVersionAttribute("snmp_community"),
+			"deletion_protection":       deletionProtectionAttribute(),
+			"force_destroy": schema.BoolAttribute{
+				Description: "When true, destroying the site first deletes every device at the site and every maintenance window for the site or its devices, including ones not managed by Terraform. Defaults to false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"labels":     labelsAttribute(),
+			"labels_all": labelsAllAttribute(),
 			"inserted_at": schema.StringAttribute{
+				CustomType:  TimestampType{},
 				Description: "The timestamp when the site was created.",
 				Computed:    true,
 				PlanModifiers: []planmodifier.String{
@@ -89,6 +120,12 @@ func (r *SiteResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 	}
 }
 
+func (r *SiteResource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	return stateUpgraders(ctx, r,
+		upgradeFromV0,
+	)
+}
+
 func (r *SiteResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -106,6 +143,19 @@ func (r *SiteResource) Configure(ctx context.Context, req resource.ConfigureRequ
 	r.client = client
 }
 
+func (r *SiteResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateWriteOnlyConfig(ctx, req.Config, "snmp_community")...)
+}
+
+func (r *SiteResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanDeletionProtection(ctx, r.client, req, resp)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	modifyPlanLabels(ctx, r.client, req, resp)
+}
+
 func (r *SiteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data SiteResourceModel
 
@@ -116,6 +166,22 @@ func (r *SiteResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	site := buildSiteFromModel(&data)
 
+	community, diags := writeOnlySecret(ctx, req.Config, nil, "snmp_community")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if community != nil {
+		site.SNMPCommunity = community
+	}
+
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	site.Labels = labels
+
 	created, err := r.client.CreateSite(site)
 	if err != nil {
 		resp.Diagnostics.AddError("Failed to create site", err.Error())
@@ -123,10 +189,12 @@ func (r *SiteResource) Create(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	data.ID = types.StringValue(created.ID)
-	data.InsertedAt = types.StringValue(created.InsertedAt)
+	data.InsertedAt = NewTimestampValue(created.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, created.Labels)...)
 	setSiteOptionalFields(&data, created)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *SiteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -149,10 +217,18 @@ func (r *SiteResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	}
 
 	data.Name = types.StringValue(site.Name)
-	data.InsertedAt = types.StringValue(site.InsertedAt)
+	data.InsertedAt = NewTimestampValue(site.InsertedAt)
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, site.Labels)...)
 	setSiteOptionalFields(&data, site)
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(defaultDeletionProtection(r.client))
+	}
+	if data.ForceDestroy.IsNull() {
+		data.ForceDestroy = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *SiteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -165,20 +241,26 @@ func (r *SiteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 
 	site := buildSiteFromModel(&data)
 
+	community, diags := writeOnlySecret(ctx, req.Config, &req.State, "snmp_community")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if community != nil {
+		site.SNMPCommunity = community
+	}
+
+	labels, diags := labelsFromModel(ctx, data.LabelsAll)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	site.Labels = labels
+
 	updated, err := r.client.UpdateSite(data.ID.ValueString(), site)
 	if err != nil {
 		if errors.Is(err, ErrNotFound) {
-			// Site was deleted outside of Terraform, recreate it
-			created, createErr := r.client.CreateSite(site)
-			if createErr != nil {
-				resp.Diagnostics.AddError("Failed to create site (after 404 on update)", createErr.Error())
-				return
-			}
-			data.ID = types.StringValue(created.ID)
-			data.InsertedAt = types.StringValue(created.InsertedAt)
-			data.Name = types.StringValue(created.Name)
-			setSiteOptionalFields(&data, created)
-			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			resp.Diagnostics.Append(deletedDuringUpdate("site", data.ID.ValueString()))
 			return
 		}
 		resp.Diagnostics.AddError("Failed to update site", err.Error())
@@ -188,7 +270,10 @@ func (r *SiteResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	data.Name = types.StringValue(updated.Name)
 	setSiteOptionalFields(&data, updated)
 
+	resp.Diagnostics.Append(setLabelsFromAPI(ctx, &data.Labels, &data.LabelsAll, updated.Labels)...)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	resp.Diagnostics.Append(setResourceIdentity(ctx, r.client, resp.Identity, data.ID.ValueString())...)
 }
 
 func (r *SiteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -199,15 +284,142 @@ func (r *SiteResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
+	resp.Diagnostics.Append(checkDeletionProtection(data.DeletionProtection, "site", data.ID.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.ForceDestroy.ValueBool() {
+		resp.Diagnostics.Append(r.deleteSiteContents(ctx, data.ID.ValueString())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	err := r.client.DeleteSite(data.ID.ValueString())
 	if err != nil {
+		// A site that still has devices cannot be deleted. Name them rather
+		// than returning the bare API error.
+		if devices, listErr := r.client.ListDevices(data.ID.ValueString()); listErr == nil && len(devices) > 0 {
+			resp.Diagnostics.AddError(
+				"Site Has Devices",
+				fmt.Sprintf("The site %s still contains %s and could not be deleted: %s\n\nDelete these devices or move them to another site, or set force_destroy = true and apply to delete them together with the site.", data.ID.ValueString(), describeDevices(devices), err),
+			)
+			return
+		}
 		resp.Diagnostics.AddError("Failed to delete site", err.Error())
 		return
 	}
 }
 
+// deleteSiteContents deletes the maintenance windows and devices at a site so
+// that the site itself can be deleted.
+func (r *SiteResource) deleteSiteContents(ctx context.Context, siteID string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	devices, err := r.client.ListDevices(siteID)
+	if err != nil {
+		diags.AddError("Failed to list devices at site", err.Error())
+		return diags
+	}
+
+	windows, err := r.client.ListMaintenanceWindows()
+	if err != nil {
+		diags.AddError("Failed to list maintenance windows", err.Error())
+		return diags
+	}
+
+	deviceIDs := make(map[string]bool, len(devices))
+	for _, device := range devices {
+		deviceIDs[device.ID] = true
+	}
+
+	// Maintenance windows go first, since they may reference the devices.
+	deletedWindows := 0
+	for _, window := range windows {
+		atSite := window.SiteID != nil && *window.SiteID == siteID
+		forDevice := window.DeviceID != nil && deviceIDs[*window.DeviceID]
+		if !atSite && !forDevice {
+			continue
+		}
+
+		tflog.Info(ctx, "Deleting maintenance window before site", map[string]any{
+			"site_id":               siteID,
+			"maintenance_window_id": window.ID,
+			"name":                  window.Name,
+		})
+		if err := r.client.DeleteMaintenanceWindow(window.ID); err != nil && !errors.Is(err, ErrNotFound) {
+			diags.AddError(
+				"Failed to delete maintenance window",
+				fmt.Sprintf("force_destroy could not delete maintenance window %s (%s), so site %s was not deleted: %s", window.Name, window.ID, siteID, err),
+			)
+			return diags
+		}
+		deletedWindows++
+	}
+
+	for i, device := range devices {
+		tflog.Info(ctx, "Deleting device before site", map[string]any{
+			"site_id":    siteID,
+			"device_id":  device.ID,
+			"ip_address": device.IPAddress,
+			"progress":   fmt.Sprintf("%d/%d", i+1, len(devices)),
+		})
+		if err := r.client.DeleteDevice(device.ID); err != nil && !errors.Is(err, ErrNotFound) {
+			diags.AddError(
+				"Failed to delete device",
+				fmt.Sprintf("force_destroy could not delete %s, so site %s was not deleted: %s", describeDevices([]Device{device}), siteID, err),
+			)
+			return diags
+		}
+	}
+
+	tflog.Info(ctx, "Deleted site contents", map[string]any{
+		"site_id":             siteID,
+		"devices":             len(devices),
+		"maintenance_windows": deletedWindows,
+	})
+
+	return diags
+}
+
+// describeDevices names devices for a diagnostic, such as
+// "2 devices: Core Router (id-1), 10.0.0.2 (id-2)". Long lists are cut short.
+func describeDevices(devices []Device) string {
+	const maxListed = 10
+
+	names := make([]string, 0, maxListed)
+	for i, device := range devices {
+		if i == maxListed {
+			names = append(names, fmt.Sprintf("and %d more", len(devices)-maxListed))
+			break
+		}
+		label := device.IPAddress
+		if device.Name != nil && *device.Name != "" {
+			label = *device.Name
+		}
+		names = append(names, fmt.Sprintf("%s (%s)", label, device.ID))
+	}
+
+	noun := "devices"
+	if len(devices) == 1 {
+		noun = "device"
+	}
+
+	return fmt.Sprintf("%d %s: %s", len(devices), noun, strings.Join(names, ", "))
+}
+
 func (r *SiteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if key, ok := parseImportKey(req.ID); ok {
+		id, err := resolveSiteImportKey(r.client, key)
+		if err != nil {
+			resp.Diagnostics.AddError("Cannot Import Site", err.Error())
+			return
+		}
+		req.ID = id
+	}
+
+	importStateWithIdentity(ctx, r.client, req, resp)
 }
 
 // buildSiteFromModel converts the Terraform model to an API Site struct.
@@ -262,7 +474,8 @@ func setSiteOptionalFields(data *SiteResourceModel, site *Site) {
 	} else {
 		data.Longitude = types.Float64Null()
 	}
-	if site.SNMPCommunity != nil {
+	// A community managed through snmp_community_wo is kept out of state.
+	if site.SNMPCommunity != nil && data.SNMPCommunityWOVersion.IsNull() {
 		data.SNMPCommunity = types.StringValue(*site.SNMPCommunity)
 	} else {
 		data.SNMPCommunity = types.StringNull()
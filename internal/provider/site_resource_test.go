@@ -10,6 +10,10 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
 )
 
 func TestAccSiteResource_basic(t *testing.T) {
@@ -200,6 +204,10 @@ func TestAccSiteResource_importState(t *testing.T) {
 				InsertedAt: "2024-01-01T00:00:00Z",
 			})
 
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/sites":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data": [{"id": "imported-site-id", "name": "Imported Site"}]}`))
+
 		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/sites/imported-site-id":
 			w.WriteHeader(http.StatusOK)
 			json.NewEncoder(w).Encode(Site{
@@ -228,6 +236,153 @@ func TestAccSiteResource_importState(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "towerops_site.test",
+				ImportState:       true,
+				ImportStateId:     "name:Imported Site",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSiteResource_identity(t *testing.T) {
+	var mu sync.Mutex
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/organization":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"data": {"id": "org-123", "name": "Test ISP"}}`))
+
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/sites":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(Site{
+				ID:         "identity-site-id",
+				Name:       "Identity Site",
+				InsertedAt: "2024-01-01T00:00:00Z",
+			})
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/sites/identity-site-id":
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(Site{
+				ID:         "identity-site-id",
+				Name:       "Identity Site",
+				InsertedAt: "2024-01-01T00:00:00Z",
+			})
+
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/sites/identity-site-id":
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_12_0),
+		},
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(server.URL),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSiteResourceConfig(server.URL, "Identity Site"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectIdentity("towerops_site.test", map[string]knownvalue.Check{
+						"id":              knownvalue.StringExact("identity-site-id"),
+						"organization_id": knownvalue.StringExact("org-123"),
+					}),
+				},
+			},
+			{
+				ResourceName:    "towerops_site.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithResourceIdentity,
+			},
+			{
+				ResourceName:    "towerops_site.test",
+				ImportState:     true,
+				ImportStateKind: resource.ImportBlockWithID,
+			},
+		},
+	})
+}
+
+func TestAccSiteResource_deletionProtection(t *testing.T) {
+	var mu sync.Mutex
+	deletes := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/sites":
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(Site{ID: "test-site-id", Name: "Core Tower", InsertedAt: "2024-01-01T00:00:00Z"})
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/sites/test-site-id":
+			json.NewEncoder(w).Encode(Site{ID: "test-site-id", Name: "Core Tower", InsertedAt: "2024-01-01T00:00:00Z"})
+
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/sites/test-site-id":
+			json.NewEncoder(w).Encode(Site{ID: "test-site-id", Name: "Core Tower", InsertedAt: "2024-01-01T00:00:00Z"})
+
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/sites/test-site-id":
+			deletes++
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := func(resourceSetting string) string {
+		return fmt.Sprintf(`
+provider "towerops" {
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
+  deletion_protection         = true
+}
+
+resource "towerops_site" "test" {
+  name = "Core Tower"
+  %s
+}
+`, server.URL, resourceSetting)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(server.URL),
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				Check:  resource.TestCheckResourceAttr("towerops_site.test", "deletion_protection", "true"),
+			},
+			{
+				Config:      config(""),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Deletion Protection Enabled`),
+			},
+			{
+				Config: config("deletion_protection = false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("towerops_site.test", "deletion_protection", "false"),
+					func(*terraform.State) error {
+						mu.Lock()
+						defer mu.Unlock()
+						if deletes != 0 {
+							return fmt.Errorf("expected the protected site not to be deleted, got %d deletes", deletes)
+						}
+						return nil
+					},
+				),
+			},
 		},
 	})
 }
@@ -235,8 +390,9 @@ func TestAccSiteResource_importState(t *testing.T) {
 func testAccSiteResourceConfig(apiURL, name string) string {
 	return fmt.Sprintf(`
 provider "towerops" {
-  token   = "test-token"
-  api_url = %q
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
 }
 
 resource "towerops_site" "test" {
@@ -248,8 +404,9 @@ resource "towerops_site" "test" {
 func testAccSiteResourceConfigWithLocation(apiURL, name, location string) string {
 	return fmt.Sprintf(`
 provider "towerops" {
-  token   = "test-token"
-  api_url = %q
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
 }
 
 resource "towerops_site" "test" {
@@ -442,8 +599,9 @@ func TestAccSiteResource_withSNMPCommunity(t *testing.T) {
 func testAccSiteResourceConfigWithSNMPCommunity(apiURL, name, community string) string {
 	return fmt.Sprintf(`
 provider "towerops" {
-  token   = "test-token"
-  api_url = %q
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
 }
 
 resource "towerops_site" "test" {
@@ -622,3 +780,80 @@ func TestAccSiteResource_recreateOn404_createError(t *testing.T) {
 		},
 	})
 }
+
+func TestAccSiteResource_defaultLabels(t *testing.T) {
+	var siteID string
+	var mu sync.Mutex
+	var labels map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/sites":
+			var body map[string]Site
+			json.NewDecoder(r.Body).Decode(&body)
+			labels = body["site"].Labels
+			siteID = "test-site-id"
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(Site{
+				ID:         siteID,
+				Name:       "Labelled Site",
+				Labels:     labels,
+				InsertedAt: "2024-01-01T00:00:00Z",
+			})
+
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/sites/"+siteID:
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(Site{
+				ID:         siteID,
+				Name:       "Labelled Site",
+				Labels:     labels,
+				InsertedAt: "2024-01-01T00:00:00Z",
+			})
+
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/sites/"+siteID:
+			w.WriteHeader(http.StatusNoContent)
+
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(server.URL),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "towerops" {
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
+
+  default_labels = {
+    environment = "production"
+    team        = "noc"
+  }
+}
+
+resource "towerops_site" "test" {
+  name = "Labelled Site"
+
+  labels = {
+    team = "towers"
+  }
+}
+`, server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("towerops_site.test", "labels.%", "1"),
+					resource.TestCheckResourceAttr("towerops_site.test", "labels.team", "towers"),
+					resource.TestCheckResourceAttr("towerops_site.test", "labels_all.%", "2"),
+					resource.TestCheckResourceAttr("towerops_site.test", "labels_all.environment", "production"),
+					resource.TestCheckResourceAttr("towerops_site.test", "labels_all.team", "towers"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,365 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SitesDataSource{}
+var _ datasource.DataSourceWithConfigure = &SitesDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &SitesDataSource{}
+
+// SitesDataSource lists existing sites matching a set of filters.
+type SitesDataSource struct {
+	client *Client
+}
+
+// SitesDataSourceModel describes the data source data model.
+type SitesDataSourceModel struct {
+	NameRegex   types.String                     `tfsdk:"name_regex"`
+	Labels      types.Map                        `tfsdk:"labels"`
+	BoundingBox *SitesDataSourceBoundingBoxModel `tfsdk:"bounding_box"`
+	Sites       []SitesDataSourceSiteModel       `tfsdk:"sites"`
+	IDsByName   types.Map                        `tfsdk:"ids_by_name"`
+}
+
+// SitesDataSourceBoundingBoxModel describes the bounding_box filter.
+type SitesDataSourceBoundingBoxModel struct {
+	North types.Float64 `tfsdk:"north"`
+	South types.Float64 `tfsdk:"south"`
+	East  types.Float64 `tfsdk:"east"`
+	West  types.Float64 `tfsdk:"west"`
+}
+
+// SitesDataSourceSiteModel describes one site in the sites list.
+type SitesDataSourceSiteModel struct {
+	ID            types.String   `tfsdk:"id"`
+	Name          types.String   `tfsdk:"name"`
+	Location      types.String   `tfsdk:"location"`
+	Address       types.String   `tfsdk:"address"`
+	Latitude      types.Float64  `tfsdk:"latitude"`
+	Longitude     types.Float64  `tfsdk:"longitude"`
+	SNMPCommunity types.String   `tfsdk:"snmp_community"`
+	Labels        types.Map      `tfsdk:"labels"`
+	InsertedAt    TimestampValue `tfsdk:"inserted_at"`
+}
+
+// siteFilter holds the filters of a towerops_sites data source. Zero fields
+// match every site.
+type siteFilter struct {
+	NameRegex   *regexp.Regexp
+	Labels      map[string]string
+	BoundingBox *boundingBox
+}
+
+// boundingBox is a geographic area. West is greater than east when the box
+// crosses the antimeridian.
+type boundingBox struct {
+	North, South, East, West float64
+}
+
+// NewSitesDataSource creates a new sites data source.
+func NewSitesDataSource() datasource.DataSource {
+	return &SitesDataSource{}
+}
+
+func (d *SitesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sites"
+}
+
+func (d *SitesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists existing TowerOps sites, optionally filtered by name, labels and location. Sites must match every filter that is set.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "Only list sites whose name matches this regular expression (Go RE2 syntax). The expression is not anchored, so use ^ and $ to match the whole name.",
+				Optional:    true,
+			},
+			"labels": schema.MapAttribute{
+				Description: "Only list sites that have all of these labels with these values.",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"bounding_box": schema.SingleNestedAttribute{
+				Description: "Only list sites with coordinates inside this area. A box whose west edge is east of its east edge crosses the antimeridian.",
+				Optional:    true,
+				Attributes: map[string]schema.Attribute{
+					"north": schema.Float64Attribute{
+						Description: "The northern edge, as a latitude (-90 to 90).",
+						Required:    true,
+						Validators:  []validator.Float64{float64validator.Between(-90, 90)},
+					},
+					"south": schema.Float64Attribute{
+						Description: "The southern edge, as a latitude (-90 to 90). Must not be north of north.",
+						Required:    true,
+						Validators:  []validator.Float64{float64validator.Between(-90, 90)},
+					},
+					"east": schema.Float64Attribute{
+						Description: "The eastern edge, as a longitude (-180 to 180).",
+						Required:    true,
+						Validators:  []validator.Float64{float64validator.Between(-180, 180)},
+					},
+					"west": schema.Float64Attribute{
+						Description: "The western edge, as a longitude (-180 to 180).",
+						Required:    true,
+						Validators:  []validator.Float64{float64validator.Between(-180, 180)},
+					},
+				},
+			},
+			"sites": schema.ListNestedAttribute{
+				Description: "The matching sites, sorted by name and then ID.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Description: "The unique identifier of the site.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the site.",
+							Computed:    true,
+						},
+						"location": schema.StringAttribute{
+							Description: "A short description of the physical location.",
+							Computed:    true,
+						},
+						"address": schema.StringAttribute{
+							Description: "The street address of the site.",
+							Computed:    true,
+						},
+						"latitude": schema.Float64Attribute{
+							Description: "The latitude of the site.",
+							Computed:    true,
+						},
+						"longitude": schema.Float64Attribute{
+							Description: "The longitude of the site.",
+							Computed:    true,
+						},
+						"snmp_community": schema.StringAttribute{
+							Description: "The default SNMP community string for devices at this site.",
+							Computed:    true,
+							Sensitive:   true,
+						},
+						"labels": schema.MapAttribute{
+							Description: "The labels on the site.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"inserted_at": schema.StringAttribute{
+							CustomType:  TimestampType{},
+							Description: "The timestamp when the site was created.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"ids_by_name": schema.MapAttribute{
+				Description: "The IDs of the matching sites, keyed by name. Names shared by more than one matching site are left out, with a warning.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *SitesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *Client, got: %T", req.ProviderData),
+		)
+		return
+	}
+
+	d.client = client
+}
+
+func (d *SitesDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var nameRegex types.String
+	var north, south types.Float64
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("name_regex"), &nameRegex)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bounding_box").AtName("north"), &north)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("bounding_box").AtName("south"), &south)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !nameRegex.IsNull() && !nameRegex.IsUnknown() {
+		if _, err := regexp.Compile(nameRegex.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Regex",
+				fmt.Sprintf("name_regex is not a valid regular expression: %s", err),
+			)
+		}
+	}
+
+	if !north.IsNull() && !north.IsUnknown() && !south.IsNull() && !south.IsUnknown() && south.ValueFloat64() > north.ValueFloat64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("bounding_box").AtName("south"),
+			"Invalid Bounding Box",
+			fmt.Sprintf("south (%g) must not be north of north (%g).", south.ValueFloat64(), north.ValueFloat64()),
+		)
+	}
+}
+
+func (d *SitesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data SitesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var filter siteFilter
+	if !data.NameRegex.IsNull() {
+		re, err := regexp.Compile(data.NameRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("name_regex"), "Invalid Name Regex", err.Error())
+			return
+		}
+		filter.NameRegex = re
+	}
+	if !data.Labels.IsNull() {
+		resp.Diagnostics.Append(data.Labels.ElementsAs(ctx, &filter.Labels, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if box := data.BoundingBox; box != nil {
+		filter.BoundingBox = &boundingBox{
+			North: box.North.ValueFloat64(),
+			South: box.South.ValueFloat64(),
+			East:  box.East.ValueFloat64(),
+			West:  box.West.ValueFloat64(),
+		}
+	}
+
+	sites, err := d.client.ListSites()
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to list sites", err.Error())
+		return
+	}
+
+	matched := filterSites(sites, filter)
+
+	data.Sites = make([]SitesDataSourceSiteModel, len(matched))
+	idsByName := make(map[string]string, len(matched))
+	var duplicates []string
+	for i, site := range matched {
+		labels, diags := siteLabelsValue(ctx, site.Labels)
+		resp.Diagnostics.Append(diags...)
+
+		data.Sites[i] = SitesDataSourceSiteModel{
+			ID:            types.StringValue(site.ID),
+			Name:          types.StringValue(site.Name),
+			Location:      types.StringPointerValue(site.Location),
+			Address:       types.StringPointerValue(site.Address),
+			Latitude:      types.Float64PointerValue(site.Latitude),
+			Longitude:     types.Float64PointerValue(site.Longitude),
+			SNMPCommunity: types.StringPointerValue(site.SNMPCommunity),
+			Labels:        labels,
+			InsertedAt:    NewTimestampValue(site.InsertedAt),
+		}
+
+		// Sites are sorted by name, so duplicates are adjacent.
+		if i > 0 && matched[i-1].Name == site.Name {
+			if len(duplicates) == 0 || duplicates[len(duplicates)-1] != site.Name {
+				duplicates = append(duplicates, site.Name)
+			}
+			delete(idsByName, site.Name)
+			continue
+		}
+		idsByName[site.Name] = site.ID
+	}
+
+	if len(duplicates) > 0 {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("ids_by_name"),
+			"Duplicate Site Names",
+			fmt.Sprintf("More than one matching site is named %s, so ids_by_name leaves these names out. Use the sites list, or narrow the filters.", quotedList(duplicates)),
+		)
+	}
+
+	ids, diags := types.MapValueFrom(ctx, types.StringType, idsByName)
+	resp.Diagnostics.Append(diags...)
+	data.IDsByName = ids
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterSites returns the sites that match every filter, sorted by name and
+// then ID.
+func filterSites(sites []Site, filter siteFilter) []Site {
+	var matched []Site
+	for _, site := range sites {
+		if filter.NameRegex != nil && !filter.NameRegex.MatchString(site.Name) {
+			continue
+		}
+		if !hasLabels(site.Labels, filter.Labels) {
+			continue
+		}
+		if filter.BoundingBox != nil && !filter.BoundingBox.contains(site.Latitude, site.Longitude) {
+			continue
+		}
+		matched = append(matched, site)
+	}
+
+	slices.SortFunc(matched, func(a, b Site) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return matched
+}
+
+// hasLabels reports whether labels has every key in want with the same value.
+func hasLabels(labels, want map[string]string) bool {
+	for key, value := range want {
+		if got, ok := labels[key]; !ok || got != value {
+			return false
+		}
+	}
+	return true
+}
+
+// contains reports whether a point is inside the box, edges included. Points
+// without coordinates are never inside.
+func (b boundingBox) contains(latitude, longitude *float64) bool {
+	if latitude == nil || longitude == nil {
+		return false
+	}
+	if *latitude < b.South || *latitude > b.North {
+		return false
+	}
+	if b.West <= b.East {
+		return *longitude >= b.West && *longitude <= b.East
+	}
+	return *longitude >= b.West || *longitude <= b.East
+}
+
+// quotedList formats names as a quoted, comma-separated list.
+func quotedList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+	return strings.Join(quoted, ", ")
+}
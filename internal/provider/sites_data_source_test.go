@@ -0,0 +1,258 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestFilterSites(t *testing.T) {
+	sites := []Site{
+		{ID: "site-3", Name: "Tower B", Latitude: float64Ptr(41.9), Longitude: float64Ptr(-87.9), Labels: map[string]string{"region": "north", "tier": "1"}},
+		{ID: "site-1", Name: "Tower A", Latitude: float64Ptr(41.8), Longitude: float64Ptr(-87.6), Labels: map[string]string{"region": "north"}},
+		{ID: "site-2", Name: "Tower B", Latitude: float64Ptr(39.8), Longitude: float64Ptr(-89.6), Labels: map[string]string{"region": "south"}},
+		{ID: "site-4", Name: "Office"},
+		{ID: "site-5", Name: "Fiji Relay", Latitude: float64Ptr(-17.7), Longitude: float64Ptr(178.1)},
+		{ID: "site-6", Name: "Samoa Relay", Latitude: float64Ptr(-13.8), Longitude: float64Ptr(-171.8)},
+	}
+
+	tests := []struct {
+		name   string
+		filter siteFilter
+		want   []string
+	}{
+		{
+			name:   "no filters",
+			filter: siteFilter{},
+			want:   []string{"site-5", "site-4", "site-6", "site-1", "site-2", "site-3"},
+		},
+		{
+			name:   "name regex",
+			filter: siteFilter{NameRegex: regexp.MustCompile(`^Tower`)},
+			want:   []string{"site-1", "site-2", "site-3"},
+		},
+		{
+			name:   "labels",
+			filter: siteFilter{Labels: map[string]string{"region": "north"}},
+			want:   []string{"site-1", "site-3"},
+		},
+		{
+			name:   "all labels must match",
+			filter: siteFilter{Labels: map[string]string{"region": "north", "tier": "1"}},
+			want:   []string{"site-3"},
+		},
+		{
+			name:   "bounding box",
+			filter: siteFilter{BoundingBox: &boundingBox{North: 42.5, South: 41.5, East: -87, West: -88}},
+			want:   []string{"site-1", "site-3"},
+		},
+		{
+			name:   "bounding box across the antimeridian",
+			filter: siteFilter{BoundingBox: &boundingBox{North: -10, South: -20, East: -170, West: 175}},
+			want:   []string{"site-5", "site-6"},
+		},
+		{
+			name: "combined",
+			filter: siteFilter{
+				NameRegex:   regexp.MustCompile(`B$`),
+				BoundingBox: &boundingBox{North: 45, South: 35, East: -85, West: -90},
+				Labels:      map[string]string{"region": "south"},
+			},
+			want: []string{"site-2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, site := range filterSites(sites, tt.filter) {
+				got = append(got, site.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSitesDataSource_Read(t *testing.T) {
+	ctx := context.Background()
+	server := newSiteDataSourceServer(t)
+
+	schemaResp := &datasource.SchemaResponse{}
+	NewSitesDataSource().Schema(ctx, datasource.SchemaRequest{}, schemaResp)
+
+	config := SitesDataSourceModel{
+		NameRegex: types.StringValue("^Tower"),
+		Labels:    types.MapNull(types.StringType),
+		IDsByName: types.MapNull(types.StringType),
+	}
+	configState := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := configState.Set(ctx, &config); diags.HasError() {
+		t.Fatalf("unexpected error building config: %v", diags)
+	}
+
+	d := &SitesDataSource{client: NewClient("test-token", server.URL)}
+	resp := &datasource.ReadResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	d.Read(ctx, datasource.ReadRequest{Config: tfsdk.Config{Schema: schemaResp.Schema, Raw: configState.Raw}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data SitesDataSourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	var ids []string
+	for _, site := range data.Sites {
+		ids = append(ids, site.ID.ValueString())
+	}
+	if want := []string{"site-1", "site-2", "site-3"}; !slices.Equal(ids, want) {
+		t.Errorf("expected sites %v, got %v", want, ids)
+	}
+	if got := data.Sites[0].SNMPCommunity.ValueString(); got != "tower-a" {
+		t.Errorf("expected snmp_community tower-a, got %s", got)
+	}
+
+	want := types.MapValueMust(types.StringType, map[string]attr.Value{"Tower A": types.StringValue("site-1")})
+	if !data.IDsByName.Equal(want) {
+		t.Errorf("expected ids_by_name %s, got %s", want, data.IDsByName)
+	}
+	if resp.Diagnostics.WarningsCount() != 1 || resp.Diagnostics.Warnings()[0].Summary() != "Duplicate Site Names" {
+		t.Errorf("expected a duplicate name warning, got: %v", resp.Diagnostics)
+	}
+}
+
+func TestSitesDataSource_ValidateConfig(t *testing.T) {
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error creating provider server: %v", err)
+	}
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	objectType := schemaResp.DataSourceSchemas["towerops_sites"].ValueType().(tftypes.Object)
+	boxType := objectType.AttributeTypes["bounding_box"].(tftypes.Object)
+
+	box := func(north, south float64) tftypes.Value {
+		return tftypes.NewValue(boxType, map[string]tftypes.Value{
+			"north": tftypes.NewValue(tftypes.Number, north),
+			"south": tftypes.NewValue(tftypes.Number, south),
+			"east":  tftypes.NewValue(tftypes.Number, -87),
+			"west":  tftypes.NewValue(tftypes.Number, -88),
+		})
+	}
+
+	tests := []struct {
+		name    string
+		attrs   map[string]tftypes.Value
+		wantErr string
+	}{
+		{name: "no filters"},
+		{name: "valid regex", attrs: map[string]tftypes.Value{"name_regex": tfString(`^Tower [A-Z]$`)}},
+		{name: "invalid regex", attrs: map[string]tftypes.Value{"name_regex": tfString(`^Tower (`)}, wantErr: "Invalid Name Regex"},
+		{name: "valid box", attrs: map[string]tftypes.Value{"bounding_box": box(42, 41)}},
+		{name: "south of north", attrs: map[string]tftypes.Value{"bounding_box": box(41, 42)}, wantErr: "Invalid Bounding Box"},
+		{name: "latitude out of range", attrs: map[string]tftypes.Value{"bounding_box": box(91, 41)}, wantErr: "Invalid Attribute Value"},
+		{name: "unknown box", attrs: map[string]tftypes.Value{"bounding_box": tftypes.NewValue(boxType, tftypes.UnknownValue)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+			for name, typ := range objectType.AttributeTypes {
+				values[name] = tftypes.NewValue(typ, nil)
+			}
+			for name, value := range tt.attrs {
+				values[name] = value
+			}
+
+			config, err := tfprotov6.NewDynamicValue(objectType, tftypes.NewValue(objectType, values))
+			if err != nil {
+				t.Fatalf("unexpected error encoding config: %v", err)
+			}
+
+			resp, err := server.ValidateDataResourceConfig(ctx, &tfprotov6.ValidateDataResourceConfigRequest{
+				TypeName: "towerops_sites",
+				Config:   &config,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var errs []string
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					errs = append(errs, d.Summary)
+				}
+			}
+
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0] != tt.wantErr {
+				t.Errorf("expected error %q, got: %v", tt.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestAccSitesDataSource_filters(t *testing.T) {
+	server := newSiteDataSourceServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories(server.URL),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSitesDataSourceConfig(server.URL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.towerops_sites.test", "sites.#", "2"),
+					resource.TestCheckResourceAttr("data.towerops_sites.test", "sites.0.id", "site-1"),
+					resource.TestCheckResourceAttr("data.towerops_sites.test", "sites.1.id", "site-2"),
+					resource.TestCheckResourceAttr("data.towerops_sites.test", "ids_by_name.%", "2"),
+					resource.TestCheckResourceAttr("data.towerops_sites.test", "ids_by_name.Tower A", "site-1"),
+					resource.TestCheckResourceAttr("data.towerops_sites.test", "ids_by_name.Tower B", "site-2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSitesDataSourceConfig(apiURL string) string {
+	return fmt.Sprintf(`
+provider "towerops" {
+  token                       = "test-token"
+  api_url                     = %q
+  skip_credentials_validation = true
+}
+
+data "towerops_sites" "test" {
+  name_regex = "^Tower"
+
+  bounding_box = {
+    north = 42.5
+    south = 41.5
+    east  = -87
+    west  = -88.5
+  }
+}
+`, apiURL)
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Every resource schema has a Version, and every resource implements
+// UpgradeState with one stateUpgradeStep per prior version. To change the
+// shape of a schema, bump its Version and append a step that rewrites the
+// previous version's state. State of any older version is rewritten by each
+// later step in turn and then decoded against the current schema, so steps
+// only need to know about the version directly before them.
+
+// stateUpgradeStep rewrites the attributes of a state from one schema version
+// into the next. Attributes missing after the last step are set to null and
+// attributes not in the current schema are dropped.
+type stateUpgradeStep func(attrs map[string]any) error
+
+// upgradeFromV0 upgrades state written before schemas were versioned. Version
+// 1 only added attributes, so there is nothing to rewrite.
+func upgradeFromV0(attrs map[string]any) error {
+	return nil
+}
+
+// stateUpgraders returns the state upgraders for r, where steps[v] upgrades
+// version v to version v+1. The schema of r must have Version len(steps).
+func stateUpgraders(ctx context.Context, r resource.Resource, steps ...stateUpgradeStep) map[int64]resource.StateUpgrader {
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	current := schemaResp.Schema
+
+	upgraders := make(map[int64]resource.StateUpgrader, len(steps))
+	for version := range steps {
+		upgraders[int64(version)] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				upgraded, err := upgradeRawState(ctx, current, req.RawState, steps[version:])
+				if err != nil {
+					resp.Diagnostics.AddError(
+						"Unable to Upgrade Resource State",
+						fmt.Sprintf("Could not upgrade state from schema version %d to %d: %s", version, current.Version, err),
+					)
+					return
+				}
+
+				resp.State.Raw = upgraded
+			},
+		}
+	}
+
+	return upgraders
+}
+
+// upgradeRawState applies steps to the JSON state in raw and decodes the
+// result against s.
+func upgradeRawState(ctx context.Context, s schema.Schema, raw *tfprotov6.RawState, steps []stateUpgradeStep) (tftypes.Value, error) {
+	if raw == nil || raw.JSON == nil {
+		return tftypes.Value{}, errors.New("prior state is not in JSON format")
+	}
+
+	// Decode numbers as json.Number so large integers survive the round trip.
+	decoder := json.NewDecoder(bytes.NewReader(raw.JSON))
+	decoder.UseNumber()
+
+	var attrs map[string]any
+	if err := decoder.Decode(&attrs); err != nil {
+		return tftypes.Value{}, fmt.Errorf("decoding prior state: %w", err)
+	}
+
+	for _, step := range steps {
+		if err := step(attrs); err != nil {
+			return tftypes.Value{}, err
+		}
+	}
+
+	upgraded, err := json.Marshal(attrs)
+	if err != nil {
+		return tftypes.Value{}, fmt.Errorf("encoding upgraded state: %w", err)
+	}
+
+	return tftypes.ValueFromJSONWithOpts(upgraded, s.Type().TerraformType(ctx), tftypes.ValueFromJSONOpts{
+		IgnoreUndefinedAttributes: true,
+	})
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TestUpgradeResourceState_fixtures upgrades the state fixtures in
+// testdata/state/v<N>/ for every resource through the provider server, the
+// same way Terraform does when it reads state written by an older release.
+func TestUpgradeResourceState_fixtures(t *testing.T) {
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New("test")())()
+	if err != nil {
+		t.Fatalf("unexpected error creating provider server: %v", err)
+	}
+
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, s := range schemaResp.ResourceSchemas {
+		if s.Version < 1 {
+			t.Errorf("expected %s schema to be versioned", name)
+			continue
+		}
+
+		for version := int64(0); version < s.Version; version++ {
+			t.Run(fmt.Sprintf("%s/v%d", name, version), func(t *testing.T) {
+				fixture, err := os.ReadFile(filepath.Join("testdata", "state", fmt.Sprintf("v%d", version), name+".json"))
+				if err != nil {
+					t.Fatalf("missing state fixture: %v", err)
+				}
+
+				resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+					TypeName: name,
+					Version:  version,
+					RawState: &tfprotov6.RawState{JSON: fixture},
+				})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				for _, d := range resp.Diagnostics {
+					if d.Severity == tfprotov6.DiagnosticSeverityError {
+						t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+					}
+				}
+
+				upgraded, err := resp.UpgradedState.Unmarshal(s.ValueType())
+				if err != nil {
+					t.Fatalf("unexpected error decoding upgraded state: %v", err)
+				}
+
+				var attrs map[string]tftypes.Value
+				if err := upgraded.As(&attrs); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				var id string
+				if err := attrs["id"].As(&id); err != nil || id == "" {
+					t.Errorf("expected id to survive the upgrade, got %v", attrs["id"])
+				}
+			})
+		}
+	}
+}
+
+func TestUpgradeResourceState_deviceV0(t *testing.T) {
+	ctx := context.Background()
+
+	fixture, err := os.ReadFile(filepath.Join("testdata", "state", "v0", "towerops_device.json"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	r := NewDeviceResource().(*DeviceResource)
+	upgrader := r.UpgradeState(ctx)[0]
+
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	resp := &resource.UpgradeStateResponse{}
+	resp.State.Schema = schemaResp.Schema
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: fixture}}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var data DeviceResourceModel
+	if diags := resp.State.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if got := data.SNMPPort.ValueInt64(); got != 161 {
+		t.Errorf("expected snmp_port 161, got %d", got)
+	}
+	if got := data.SNMPv3Username.ValueString(); got != "snmpuser" {
+		t.Errorf("expected snmpv3_username snmpuser, got %q", got)
+	}
+	if !data.Labels.IsNull() || !data.LabelsAll.IsNull() {
+		t.Errorf("expected labels added in version 1 to be null, got %v and %v", data.Labels, data.LabelsAll)
+	}
+}
+
+func TestUpgradeRawState_steps(t *testing.T) {
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewSiteResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	renameSite := func(attrs map[string]any) error {
+		attrs["name"] = attrs["site_name"]
+		delete(attrs, "site_name")
+		return nil
+	}
+	failing := func(attrs map[string]any) error {
+		return fmt.Errorf("boom")
+	}
+
+	raw := &tfprotov6.RawState{JSON: []byte(`{"id": "site-1", "site_name": "Tower", "latitude": 33.4356, "removed": true}`)}
+
+	upgraded, err := upgradeRawState(ctx, schemaResp.Schema, raw, []stateUpgradeStep{upgradeFromV0, renameSite})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var attrs map[string]tftypes.Value
+	if err := upgraded.As(&attrs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var name string
+	if err := attrs["name"].As(&name); err != nil || name != "Tower" {
+		t.Errorf("expected name Tower, got %v", attrs["name"])
+	}
+	if !attrs["address"].IsNull() {
+		t.Errorf("expected missing address to be null, got %v", attrs["address"])
+	}
+
+	if _, err := upgradeRawState(ctx, schemaResp.Schema, raw, []stateUpgradeStep{failing}); err == nil {
+		t.Error("expected error from failing step")
+	}
+	if _, err := upgradeRawState(ctx, schemaResp.Schema, &tfprotov6.RawState{}, nil); err == nil {
+		t.Error("expected error for state without JSON")
+	}
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = TimestampType{}
+var _ basetypes.StringValuableWithSemanticEquals = TimestampValue{}
+var _ xattr.ValidateableAttribute = TimestampValue{}
+
+// TimestampType is a string attribute type holding an RFC 3339 timestamp.
+// Values that name the same instant are semantically equal, so the API
+// returning "2024-03-15T02:00:00.000000Z" for a configured
+// "2024-03-15T02:00:00Z" does not cause a diff.
+type TimestampType struct {
+	basetypes.StringType
+}
+
+func (t TimestampType) Equal(o attr.Type) bool {
+	other, ok := o.(TimestampType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t TimestampType) String() string {
+	return "TimestampType"
+}
+
+func (t TimestampType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return TimestampValue{StringValue: in}, nil
+}
+
+func (t TimestampType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return TimestampValue{StringValue: stringValue}, nil
+}
+
+func (t TimestampType) ValueType(ctx context.Context) attr.Value {
+	return TimestampValue{}
+}
+
+// TimestampValue is the value of a TimestampType attribute.
+type TimestampValue struct {
+	basetypes.StringValue
+}
+
+// NewTimestampValue returns a timestamp value from an API response. The API
+// leaves unset times empty, which becomes null.
+func NewTimestampValue(value string) TimestampValue {
+	if value == "" {
+		return NewTimestampNull()
+	}
+
+	return TimestampValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewTimestampPointerValue returns a timestamp value that is null when value
+// is nil.
+func NewTimestampPointerValue(value *string) TimestampValue {
+	if value == nil {
+		return NewTimestampNull()
+	}
+
+	return NewTimestampValue(*value)
+}
+
+// NewTimestampNull returns a null timestamp value.
+func NewTimestampNull() TimestampValue {
+	return TimestampValue{StringValue: basetypes.NewStringNull()}
+}
+
+// NewTimestampUnknown returns an unknown timestamp value.
+func NewTimestampUnknown() TimestampValue {
+	return TimestampValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+func (v TimestampValue) Equal(o attr.Value) bool {
+	other, ok := o.(TimestampValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v TimestampValue) Type(ctx context.Context) attr.Type {
+	return TimestampType{}
+}
+
+// StringSemanticEquals reports whether both values name the same instant,
+// whatever their precision or UTC offset.
+func (v TimestampValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(TimestampValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	current, err := time.Parse(time.RFC3339Nano, v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	proposed, err := time.Parse(time.RFC3339Nano, newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+
+	return current.Equal(proposed), diags
+}
+
+// ValidateAttribute reports configured values that are not RFC 3339
+// timestamps.
+func (v TimestampValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339Nano, v.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("%q is not an RFC 3339 timestamp. Use a value such as 2024-01-15T02:00:00Z or 2024-01-15T02:00:00-06:00.", v.ValueString()),
+		)
+	}
+}
+
+// ValueTime parses the timestamp. The value must be known and not null.
+func (v TimestampValue) ValueTime() (time.Time, error) {
+	return time.Parse(time.RFC3339Nano, v.ValueString())
+}
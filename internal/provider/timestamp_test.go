@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestTimestampValue_StringSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		current, proposed string
+		want              bool
+	}{
+		{"2024-03-15T02:00:00Z", "2024-03-15T02:00:00Z", true},
+		{"2024-03-15T02:00:00Z", "2024-03-15T02:00:00.000000Z", true},
+		{"2024-03-15T02:00:00Z", "2024-03-15T02:00:00+00:00", true},
+		{"2024-03-15T02:00:00Z", "2024-03-14T20:00:00-06:00", true},
+		{"2024-03-15T02:00:00Z", "2024-03-15T02:00:01Z", false},
+		{"2024-03-15T02:00:00Z", "2024-03-15T02:00:00.5Z", false},
+		{"not a time", "not a time", false},
+		{"2024-03-15T02:00:00Z", "2024-03-15", false},
+	}
+
+	for _, tt := range tests {
+		got, diags := NewTimestampValue(tt.current).StringSemanticEquals(ctx, NewTimestampValue(tt.proposed))
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if got != tt.want {
+			t.Errorf("StringSemanticEquals(%q, %q) = %v, want %v", tt.current, tt.proposed, got, tt.want)
+		}
+	}
+}
+
+func TestTimestampValue_ValidateAttribute(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		value     TimestampValue
+		wantError bool
+	}{
+		{NewTimestampValue("2024-03-15T02:00:00Z"), false},
+		{NewTimestampValue("2024-03-15T02:00:00.123456+05:30"), false},
+		{NewTimestampNull(), false},
+		{NewTimestampUnknown(), false},
+		{NewTimestampValue("2024-03-15 02:00:00"), true},
+		{NewTimestampValue("2024-03-15"), true},
+		{NewTimestampValue("2024-03-15T02:00:00"), true},
+	}
+
+	for _, tt := range tests {
+		resp := &xattr.ValidateAttributeResponse{}
+		tt.value.ValidateAttribute(ctx, xattr.ValidateAttributeRequest{Path: path.Root("starts_at")}, resp)
+		if resp.Diagnostics.HasError() != tt.wantError {
+			t.Errorf("ValidateAttribute(%s): expected error %v, got: %v", tt.value, tt.wantError, resp.Diagnostics)
+		}
+	}
+}
+
+func TestTimestampType_ValueFromTerraform(t *testing.T) {
+	ctx := context.Background()
+
+	value, err := TimestampType{}.ValueFromTerraform(ctx, tftypes.NewValue(tftypes.String, "2024-03-15T02:00:00Z"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	timestamp, ok := value.(TimestampValue)
+	if !ok {
+		t.Fatalf("expected TimestampValue, got %T", value)
+	}
+	got, err := timestamp.ValueTime()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2024, 3, 15, 2, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
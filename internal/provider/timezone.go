@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata" // so validation doesn't depend on the host's tz database
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ basetypes.StringTypable = TimezoneType{}
+var _ basetypes.StringValuableWithSemanticEquals = TimezoneValue{}
+var _ xattr.ValidateableAttribute = TimezoneValue{}
+
+//go:generate go run gen_timezones.go
+
+// timezoneSet holds timezoneNames for lookups.
+var timezoneSet = func() map[string]bool {
+	set := make(map[string]bool, len(timezoneNames))
+	for _, name := range timezoneNames {
+		set[name] = true
+	}
+	return set
+}()
+
+// TimezoneType is a string attribute type holding an IANA time zone name.
+// Deprecated aliases such as US/Central are accepted with a warning and are
+// equal to the zone they link to.
+type TimezoneType struct {
+	basetypes.StringType
+}
+
+func (t TimezoneType) Equal(o attr.Type) bool {
+	other, ok := o.(TimezoneType)
+	if !ok {
+		return false
+	}
+
+	return t.StringType.Equal(other.StringType)
+}
+
+func (t TimezoneType) String() string {
+	return "TimezoneType"
+}
+
+func (t TimezoneType) ValueFromString(ctx context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return TimezoneValue{StringValue: in}, nil
+}
+
+func (t TimezoneType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	return TimezoneValue{StringValue: stringValue}, nil
+}
+
+func (t TimezoneType) ValueType(ctx context.Context) attr.Value {
+	return TimezoneValue{}
+}
+
+// TimezoneValue is the value of a TimezoneType attribute.
+type TimezoneValue struct {
+	basetypes.StringValue
+}
+
+// NewTimezoneValue returns a known time zone value.
+func NewTimezoneValue(value string) TimezoneValue {
+	return TimezoneValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+func (v TimezoneValue) Equal(o attr.Value) bool {
+	other, ok := o.(TimezoneValue)
+	if !ok {
+		return false
+	}
+
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v TimezoneValue) Type(ctx context.Context) attr.Type {
+	return TimezoneType{}
+}
+
+// Canonical returns the zone name to send to the API, resolving deprecated
+// aliases.
+func (v TimezoneValue) Canonical() string {
+	return canonicalTimezone(v.ValueString())
+}
+
+// StringSemanticEquals reports whether both values name the same zone once
+// aliases are resolved.
+func (v TimezoneValue) StringSemanticEquals(ctx context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(TimezoneValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return v.Canonical() == newValue.Canonical(), diags
+}
+
+// ValidateAttribute rejects unknown zone names, suggesting the closest valid
+// one, and warns about deprecated aliases.
+func (v TimezoneValue) ValidateAttribute(ctx context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+
+	name := v.ValueString()
+	if timezoneSet[name] {
+		return
+	}
+
+	if target, ok := timezoneAliases[name]; ok {
+		resp.Diagnostics.AddAttributeWarning(
+			req.Path,
+			"Deprecated Time Zone",
+			fmt.Sprintf("%q is a deprecated alias of %q, which is sent to the API instead. Use %q to avoid this warning.", name, target, target),
+		)
+		return
+	}
+
+	// Zones added to the tz database after timezoneNames was built are
+	// accepted when Go knows them, which also keeps API responses valid.
+	if isLoadableTimezone(name) {
+		return
+	}
+
+	detail := fmt.Sprintf("%q is not an IANA time zone name, such as America/Chicago or Europe/London.", name)
+	if suggestion := suggestTimezone(name); suggestion != "" {
+		detail += fmt.Sprintf(" Did you mean %q?", suggestion)
+	}
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid Time Zone", detail)
+}
+
+// isLoadableTimezone reports whether Go's tz database has a zone called name.
+func isLoadableTimezone(name string) bool {
+	// LoadLocation maps these to UTC and the host's zone.
+	if name == "" || name == "Local" {
+		return false
+	}
+
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// canonicalTimezone resolves a deprecated alias to the zone it links to.
+// Other names are returned unchanged.
+func canonicalTimezone(name string) string {
+	if target, ok := timezoneAliases[name]; ok {
+		return target
+	}
+	return name
+}
+
+// suggestTimezone returns the valid zone name closest to name, or "" when
+// nothing is close. Both the full name and the city part are compared, so
+// "Chicgo" and "America/Chicgo" both suggest America/Chicago.
+func suggestTimezone(name string) string {
+	input := strings.ToLower(strings.TrimSpace(name))
+	if input == "" {
+		return ""
+	}
+	inputCity := input[strings.LastIndex(input, "/")+1:]
+
+	// Candidates are ranked by the closer of the two distances, with ties
+	// going to the closer full name.
+	best, bestDistance, bestFull := "", -1, 0
+	consider := func(candidate, target string) {
+		lower := strings.ToLower(candidate)
+		full := levenshtein(input, lower)
+		distance := min(full, levenshtein(inputCity, lower[strings.LastIndex(lower, "/")+1:]))
+		if bestDistance < 0 || distance < bestDistance || distance == bestDistance && full < bestFull {
+			best, bestDistance, bestFull = target, distance, full
+		}
+	}
+
+	for _, candidate := range timezoneNames {
+		consider(candidate, candidate)
+	}
+	for _, alias := range slices.Sorted(maps.Keys(timezoneAliases)) {
+		consider(alias, timezoneAliases[alias])
+	}
+
+	// Allow roughly one edit per four characters, so short inputs only
+	// match near misses.
+	if bestDistance > len(inputCity)/4+1 {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+func validateTimezone(t *testing.T, value TimezoneValue) diag.Diagnostics {
+	t.Helper()
+
+	resp := &xattr.ValidateAttributeResponse{}
+	value.ValidateAttribute(context.Background(), xattr.ValidateAttributeRequest{Path: path.Root("timezone")}, resp)
+	return resp.Diagnostics
+}
+
+func TestTimezoneValue_ValidateAttribute(t *testing.T) {
+	for _, name := range []string{"America/Chicago", "Europe/Oslo", "UTC", "Etc/GMT+6", "America/Argentina/Buenos_Aires"} {
+		if diags := validateTimezone(t, NewTimezoneValue(name)); len(diags) > 0 {
+			t.Errorf("expected %q to be valid, got: %v", name, diags)
+		}
+	}
+
+	diags := validateTimezone(t, NewTimezoneValue("US/Central"))
+	if diags.HasError() || diags.WarningsCount() != 1 {
+		t.Fatalf("expected a single warning for US/Central, got: %v", diags)
+	}
+	if detail := diags.Warnings()[0].Detail(); !strings.Contains(detail, `"America/Chicago"`) {
+		t.Errorf("expected warning to name America/Chicago, got: %s", detail)
+	}
+
+	for _, name := range []string{"Local", "", "Central Time", "America/Chicgo"} {
+		if diags := validateTimezone(t, NewTimezoneValue(name)); !diags.HasError() {
+			t.Errorf("expected error for %q", name)
+		}
+	}
+
+	if diags := validateTimezone(t, NewTimezoneValue("America/Chicgo")); !strings.Contains(diags.Errors()[0].Detail(), `Did you mean "America/Chicago"?`) {
+		t.Errorf("expected a suggestion, got: %s", diags.Errors()[0].Detail())
+	}
+}
+
+func TestSuggestTimezone(t *testing.T) {
+	tests := []struct {
+		name, want string
+	}{
+		{"America/Chicgo", "America/Chicago"},
+		{"Chicgo", "America/Chicago"},
+		{"america/new_york", "America/New_York"},
+		{"Europe/Londn", "Europe/London"},
+		{"US/Centrl", "America/Chicago"},
+		{"Asia/Calcuta", "Asia/Kolkata"},
+		{"Central Time", ""},
+		{"xyz", ""},
+	}
+
+	for _, tt := range tests {
+		if got := suggestTimezone(tt.name); got != tt.want {
+			t.Errorf("suggestTimezone(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestTimezoneValue_StringSemanticEquals(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		current, proposed string
+		want              bool
+	}{
+		{"America/Chicago", "America/Chicago", true},
+		{"US/Central", "America/Chicago", true},
+		{"Asia/Calcutta", "Asia/Kolkata", true},
+		{"America/Chicago", "America/Denver", false},
+		{"Europe/Oslo", "Europe/Berlin", false},
+	}
+
+	for _, tt := range tests {
+		got, diags := NewTimezoneValue(tt.current).StringSemanticEquals(ctx, NewTimezoneValue(tt.proposed))
+		if diags.HasError() {
+			t.Fatalf("unexpected error: %v", diags)
+		}
+		if got != tt.want {
+			t.Errorf("StringSemanticEquals(%q, %q) = %v, want %v", tt.current, tt.proposed, got, tt.want)
+		}
+	}
+}
+
+// TestTimezoneNames checks the built-in zone list against Go's tz database.
+func TestTimezoneNames(t *testing.T) {
+	for _, name := range timezoneNames {
+		if _, err := time.LoadLocation(name); err != nil {
+			t.Errorf("zone %q: %v", name, err)
+		}
+	}
+	for alias, target := range timezoneAliases {
+		if !timezoneSet[target] {
+			t.Errorf("alias %q links to %q, which is not a listed zone", alias, target)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Secrets that can be managed write-only have three attributes: the regular
+// sensitive attribute, <attr>_wo holding the write-only value, and
+// <attr>_wo_version which is stored in state so that changing it tells
+// Terraform to send the write-only value again.
+
+// writeOnlyAttribute returns the schema for the write-only form of attr.
+func writeOnlyAttribute(attr, description string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Description: fmt.Sprintf("%s Write-only: sent to the API but never stored in plan or state. Conflicts with %s and requires %s_wo_version. Requires Terraform 1.11 or later.", description, attr, attr),
+		Optional:    true,
+		Sensitive:   true,
+		WriteOnly:   true,
+	}
+}
+
+// writeOnlyVersionAttribute returns the schema for the version trigger of attr_wo.
+func writeOnlyVersionAttribute(attr string) schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Description: fmt.Sprintf("The version of %s_wo. Change this value to send a new %s_wo to the API.", attr, attr),
+		Optional:    true,
+	}
+}
+
+// validateWriteOnlyConfig checks that attr is set either directly or through
+// attr_wo together with attr_wo_version, but not both.
+func validateWriteOnlyConfig(ctx context.Context, config tfsdk.Config, attr string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var value, woValue types.String
+	var woVersion types.Int64
+
+	diags.Append(config.GetAttribute(ctx, path.Root(attr), &value)...)
+	diags.Append(config.GetAttribute(ctx, path.Root(attr+"_wo"), &woValue)...)
+	diags.Append(config.GetAttribute(ctx, path.Root(attr+"_wo_version"), &woVersion)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !value.IsNull() && (!woValue.IsNull() || !woVersion.IsNull()) {
+		diags.AddAttributeError(
+			path.Root(attr+"_wo"),
+			"Conflicting Attributes",
+			fmt.Sprintf("%s cannot be combined with %s_wo or %s_wo_version. Use one or the other.", attr, attr, attr),
+		)
+	}
+
+	if !woValue.IsNull() && woVersion.IsNull() {
+		diags.AddAttributeError(
+			path.Root(attr+"_wo_version"),
+			"Missing Write-Only Version",
+			fmt.Sprintf("%s_wo_version must be set with %s_wo. Write-only values are not stored, so Terraform only sends a new value when the version changes.", attr, attr),
+		)
+	}
+
+	return diags
+}
+
+// writeOnlySecret returns the value of attr_wo from the configuration when it
+// needs to be sent to the API: when state is nil (on create) or when
+// attr_wo_version differs from state. Otherwise it returns nil.
+func writeOnlySecret(ctx context.Context, config tfsdk.Config, state *tfsdk.State, attr string) (*string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var woVersion types.Int64
+
+	diags.Append(config.GetAttribute(ctx, path.Root(attr+"_wo_version"), &woVersion)...)
+	if diags.HasError() || woVersion.IsNull() {
+		return nil, diags
+	}
+
+	if state != nil {
+		var priorVersion types.Int64
+		diags.Append(state.GetAttribute(ctx, path.Root(attr+"_wo_version"), &priorVersion)...)
+		if diags.HasError() || priorVersion.Equal(woVersion) {
+			return nil, diags
+		}
+	}
+
+	var woValue types.String
+	diags.Append(config.GetAttribute(ctx, path.Root(attr+"_wo"), &woValue)...)
+	if diags.HasError() || woValue.IsNull() {
+		return nil, diags
+	}
+
+	v := woValue.ValueString()
+	return &v, diags
+}
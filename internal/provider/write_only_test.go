@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newOrganizationConfig(t *testing.T, model OrganizationResourceModel) (tfsdk.Config, tfsdk.State) {
+	t.Helper()
+	ctx := context.Background()
+
+	schemaResp := &resource.SchemaResponse{}
+	NewOrganizationResource().Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// tfsdk.Plan is used only to encode the model into a raw value.
+	raw := tfsdk.Plan{Schema: schemaResp.Schema}
+	if diags := raw.Set(ctx, &model); diags.HasError() {
+		t.Fatalf("unexpected error building config: %v", diags)
+	}
+
+	return tfsdk.Config{Schema: schemaResp.Schema, Raw: raw.Raw},
+		tfsdk.State{Schema: schemaResp.Schema, Raw: raw.Raw}
+}
+
+func organizationModel(community, communityWO types.String, version types.Int64) OrganizationResourceModel {
+	return OrganizationResourceModel{
+		ID:                     types.StringNull(),
+		Name:                   types.StringNull(),
+		Slug:                   types.StringNull(),
+		UseSites:               types.BoolValue(true),
+		SnmpCommunity:          community,
+		SnmpCommunityWO:        communityWO,
+		SnmpCommunityWOVersion: version,
+	}
+}
+
+func TestValidateWriteOnlyConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		model   OrganizationResourceModel
+		wantErr string
+	}{
+		{
+			name:  "plain attribute",
+			model: organizationModel(types.StringValue("public"), types.StringNull(), types.Int64Null()),
+		},
+		{
+			name:  "write-only with version",
+			model: organizationModel(types.StringNull(), types.StringValue("secret"), types.Int64Value(1)),
+		},
+		{
+			name:    "both forms",
+			model:   organizationModel(types.StringValue("public"), types.StringValue("secret"), types.Int64Value(1)),
+			wantErr: "Conflicting Attributes",
+		},
+		{
+			name:    "missing version",
+			model:   organizationModel(types.StringNull(), types.StringValue("secret"), types.Int64Null()),
+			wantErr: "Missing Write-Only Version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, _ := newOrganizationConfig(t, tt.model)
+			diags := validateWriteOnlyConfig(context.Background(), config, "snmp_community")
+
+			if tt.wantErr == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				return
+			}
+			if !diags.HasError() || diags.Errors()[0].Summary() != tt.wantErr {
+				t.Fatalf("expected %q error, got: %v", tt.wantErr, diags)
+			}
+		})
+	}
+}
+
+func TestWriteOnlySecret(t *testing.T) {
+	ctx := context.Background()
+	config, _ := newOrganizationConfig(t, organizationModel(types.StringNull(), types.StringValue("secret"), types.Int64Value(2)))
+	_, sameVersion := newOrganizationConfig(t, organizationModel(types.StringNull(), types.StringNull(), types.Int64Value(2)))
+	_, oldVersion := newOrganizationConfig(t, organizationModel(types.StringNull(), types.StringNull(), types.Int64Value(1)))
+
+	tests := []struct {
+		name  string
+		state *tfsdk.State
+		want  string
+	}{
+		{name: "create", state: nil, want: "secret"},
+		{name: "version unchanged", state: &sameVersion, want: ""},
+		{name: "version changed", state: &oldVersion, want: "secret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := writeOnlySecret(ctx, config, tt.state, "snmp_community")
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if tt.want == "" {
+				if got != nil {
+					t.Fatalf("expected no value, got %q", *got)
+				}
+				return
+			}
+			if got == nil || *got != tt.want {
+				t.Fatalf("expected %q, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -2,15 +2,28 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/towerops/terraform-provider-towerops/internal/export"
 	"github.com/towerops/terraform-provider-towerops/internal/provider"
 )
 
 var version = "dev"
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	opts := providerserver.ServeOpts{
 		Address: "registry.terraform.io/towerops/towerops",
 	}
@@ -20,3 +33,43 @@ func main() {
 		log.Fatal(err.Error())
 	}
 }
+
+// runExport implements the export subcommand, which writes Terraform
+// configuration and import blocks for an existing organization.
+func runExport(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: terraform-provider-towerops export [--token TOKEN] [--api-url URL] [--out DIR]")
+		fmt.Fprintln(flags.Output())
+		fmt.Fprintln(flags.Output(), "Writes Terraform configuration and import blocks for every object in the organization of the token.")
+		fmt.Fprintln(flags.Output())
+		flags.PrintDefaults()
+	}
+	token := flags.String("token", os.Getenv("TOWEROPS_TOKEN"), "TowerOps API token. Defaults to the TOWEROPS_TOKEN environment variable.")
+	apiURL := flags.String("api-url", "", "TowerOps API base URL. Defaults to "+provider.DefaultBaseURL+".")
+	outDir := flags.String("out", ".", "Directory to write the .tf files to.")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments: %v", flags.Args())
+	}
+	if *token == "" {
+		return errors.New("a token is required. Pass --token or set TOWEROPS_TOKEN")
+	}
+
+	client := provider.NewClient(*token, *apiURL)
+	// Export only reads, so refuse anything else.
+	client.ReadOnly = true
+
+	if err := export.Export(client, *outDir, os.Stderr); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote Terraform configuration to %s. Run terraform plan to review the imports.\n", *outDir)
+	return nil
+}